@@ -2,7 +2,11 @@ module github.com/leandroluk/go-joi
 
 go 1.25.0
 
-require github.com/stretchr/testify v1.11.0
+require (
+	github.com/rivo/uniseg v0.4.7
+	github.com/stretchr/testify v1.11.0
+	golang.org/x/text v0.40.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
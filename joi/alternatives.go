@@ -0,0 +1,146 @@
+package joi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// --- messages ---
+
+type AlternativesMsg string
+
+var (
+	AlternativesMsgAny   AlternativesMsg = "alternatives_any"
+	AlternativesMsgMatch AlternativesMsg = "alternatives_match"
+	AlternativesMsgOne   AlternativesMsg = "alternatives_one"
+	AlternativesMsgAll   AlternativesMsg = "alternatives_all"
+)
+
+var AlternativesMsgMap = map[AlternativesMsg]string{
+	AlternativesMsgAny:   "{{#label}} does not match any of the allowed types",
+	AlternativesMsgMatch: "{{#label}} does not match any of the allowed alternatives ({{#message}})",
+	AlternativesMsgOne:   "{{#label}} matches more than one allowed type",
+	AlternativesMsgAll:   "{{#label}} does not match all of the required types ({{#message}})",
+}
+
+// --- structs ---
+
+// MatchMode is how Alternatives matches its schemas, set with Match.
+type MatchMode string
+
+const (
+	MatchAny MatchMode = "any"
+	MatchOne MatchMode = "one"
+	MatchAll MatchMode = "all"
+)
+
+type AlternativesSchema struct {
+	*AnySchema[*AlternativesSchema]
+	matches []Schema
+	mode    MatchMode
+}
+
+var _ Schema = (*AlternativesSchema)(nil)
+
+// --- methods ---
+
+func (s *AlternativesSchema) Clone() *AlternativesSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *AlternativesSchema) rebase(base *AnySchema[*AlternativesSchema]) *AlternativesSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+// Try adds schemas to the list of alternatives.
+func (s *AlternativesSchema) Try(schemas ...Schema) *AlternativesSchema {
+	c := s.Clone()
+	c.matches = slices.Concat(s.matches, schemas)
+	return c
+}
+
+// Match sets how alternatives are matched: MatchAny (the default) accepts the
+// first matching schema, MatchOne requires exactly one match and MatchAll
+// requires every schema to match. The parsed value is the one of the first
+// match.
+func (s *AlternativesSchema) Match(mode MatchMode) *AlternativesSchema {
+	switch mode {
+	case MatchAny, MatchOne, MatchAll:
+	default:
+		panic(fmt.Sprintf("joi: unknown match mode %q", mode))
+	}
+	c := s.Clone()
+	c.mode = mode
+	return c
+}
+
+func (s *AlternativesSchema) validateInner(value any, opts ValidateOptions) (any, ValidationErrors) {
+	var (
+		parsed  any
+		matched int
+		details ValidationErrors
+		typed   bool
+	)
+
+	for _, schema := range s.matches {
+		val, errs := schema.ValidateWithOpts(value, opts.trial())
+		if len(errs) == 0 {
+			if matched == 0 {
+				parsed = val
+			}
+			matched++
+			if s.mode == "" || s.mode == MatchAny {
+				break
+			}
+			continue
+		}
+		details = append(details, errs...)
+		if !slices.ContainsFunc(errs, func(e ValidationError) bool { return strings.HasSuffix(e.Type, "_base") }) {
+			typed = true
+		}
+	}
+
+	var name AlternativesMsg
+	switch {
+	case s.mode == MatchAll && matched < len(s.matches):
+		name = AlternativesMsgAll
+	case s.mode == MatchOne && matched > 1:
+		name = AlternativesMsgOne
+	case matched > 0:
+		return parsed, nil
+	case typed:
+		name = AlternativesMsgMatch
+	default:
+		name = AlternativesMsgAny
+	}
+
+	msgs := make([]string, len(details))
+	for i, d := range details {
+		msgs[i] = d.Msg
+	}
+	ctx := map[string]any{
+		"label":   Coalesce(s.label, opts.path(), "value"),
+		"value":   value,
+		"details": details,
+		"message": strings.Join(msgs, "; "),
+	}
+	opts.report(1)
+	return value, ValidationErrors{newError(string(name), AlternativesMsgMap[name], ctx, value, opts)}
+}
+
+// --- constructor ---
+
+// Alternatives creates a schema matching a value against several schemas, e.g.
+// a string UUID or a positive integer.
+func Alternatives(schemas ...Schema) *AlternativesSchema {
+	s := &AlternativesSchema{matches: schemas}
+	s.AnySchema = &AnySchema[*AlternativesSchema]{
+		self:  s,
+		label: "value",
+		rules: []Rule{},
+	}
+	return s
+}
@@ -1,5 +1,10 @@
 package joi
 
+import (
+	"fmt"
+	"slices"
+)
+
 // --- messages ---
 
 type AnyMsg string
@@ -10,6 +15,9 @@ var (
 	AnyMsgRequired AnyMsg = "any_required"
 	AnyMsgInvalid  AnyMsg = "any_invalid"
 	AnyMsgValid    AnyMsg = "any_valid"
+	AnyMsgRef      AnyMsg = "any_ref"
+	AnyMsgUnknown  AnyMsg = "any_unknown"
+	AnyMsgDecode   AnyMsg = "any_decode"
 )
 
 var AnyMsgMap = map[AnyMsg]string{
@@ -18,39 +26,187 @@ var AnyMsgMap = map[AnyMsg]string{
 	AnyMsgRequired: "{{#label}} is required",
 	AnyMsgInvalid:  "{{#label}} contains an invalid value",
 	AnyMsgValid:    "{{#label}} must be one of {{#valid}}",
+	AnyMsgRef:      "{{#label}} {{#arg}} references {{#ref}} which {{#reason}}",
+	AnyMsgUnknown:  "{{#label}} is not allowed",
+	AnyMsgDecode:   "{{#label}} cannot be decoded into {{#type}}",
 }
 
 // --- structs ---
 
 type DefaultValue struct {
 	value any
+	fn    DefaultFunc
 }
 
+// DefaultFunc computes a default value. parent is the object holding the
+// value (nil at the top level), where the keys validated before it hold their
+// parsed values.
+type DefaultFunc func(parent map[string]any, helpers DefaultHelpers) (any, error)
+
+// DefaultHelpers gives a DefaultFunc access to the validation context.
+type DefaultHelpers struct {
+	// Path is the path of the value being defaulted.
+	Path string
+	// Context is the Context given in ValidateOptions.
+	Context map[string]any
+
+	opts ValidateOptions
+}
+
+// Ref resolves a reference (see Ref) from the value being defaulted, e.g.
+// h.Ref("firstName") for a sibling.
+func (h DefaultHelpers) Ref(key string) any {
+	v, _ := Ref(key).resolve(nil, h.opts)
+	return v
+}
+
+// get returns the default value; literal maps and slices are copied so the
+// output can be changed without affecting the schema.
+func (d *DefaultValue) get(opts ValidateOptions) (any, error) {
+	if d.fn == nil {
+		return deepClone(d.value), nil
+	}
+	var parent map[string]any
+	if len(opts.ancestors) > 0 {
+		parent, _ = opts.ancestors[0].(map[string]any)
+	}
+	return d.fn(parent, DefaultHelpers{Path: opts.path(), Context: opts.Context, opts: opts})
+}
+
+// Presence tells whether a value must, may or must not be present (non-nil).
+type Presence string
+
+const (
+	PresenceOptional  Presence = "optional"
+	PresenceRequired  Presence = "required"
+	PresenceForbidden Presence = "forbidden"
+)
+
 type AnySchema[T any] struct {
-	path         string
 	label        string
 	rules        []Rule
 	defaultValue *DefaultValue
-	self         T
+	// presence is empty until set with Required, Optional or Forbidden, so
+	// the Presence option applies.
+	presence    Presence
+	presenceMsg string
+	strict      bool
+	strip       bool
+	whens       []when
+	self        T
 }
 
 var _ Schema = (*AnySchema[AnySchema[any]])(nil)
 
+// rebaser is implemented by the schemas embedding AnySchema, so a cloned base
+// can be wrapped by a copy of the outer schema.
+type rebaser[T any] interface {
+	rebase(base *AnySchema[T]) T
+}
+
 // --- methods ---
 
+// Clone returns a copy of the schema that can be extended without affecting
+// the original. Every builder method works on a clone, so a base schema can be
+// reused and extended in different directions.
+func (s *AnySchema[T]) Clone() *AnySchema[T] {
+	c := *s
+	c.rules = slices.Clone(s.rules)
+	if r, ok := any(s.self).(rebaser[T]); ok {
+		c.self = r.rebase(&c)
+	} else if self, ok := any(&c).(T); ok {
+		c.self = self
+	}
+	return &c
+}
+
+func (s *AnySchema[T]) withRule(r Rule) *AnySchema[T] {
+	c := s.Clone()
+	c.rules = append(c.rules, r)
+	return c
+}
+
 func (s *AnySchema[T]) Label(label string) *AnySchema[T] {
-	s.label = label
-	return s
+	c := s.Clone()
+	c.label = label
+	return c
 }
 
 func (s *AnySchema[T]) Default(value any) *AnySchema[T] {
-	s.defaultValue = &DefaultValue{value: value}
-	return s
+	c := s.Clone()
+	c.defaultValue = &DefaultValue{value: value}
+	return c
+}
+
+// DefaultFunc sets a default computed when the value is missing, e.g. a
+// generated id, a timestamp or a value derived from its siblings.
+func (s *AnySchema[T]) DefaultFunc(fn DefaultFunc) *AnySchema[T] {
+	c := s.Clone()
+	c.defaultValue = &DefaultValue{fn: fn}
+	return c
+}
+
+// Strict disables type conversion for this schema and its children, the same
+// as validating it with Convert set to false.
+func (s *AnySchema[T]) Strict() *AnySchema[T] {
+	c := s.Clone()
+	c.strict = true
+	return c
+}
+
+// Strip removes the value from the output of the object or array holding it,
+// once it has been validated.
+func (s *AnySchema[T]) Strip() *AnySchema[T] {
+	c := s.Clone()
+	c.strip = true
+	return c
+}
+
+func (s *AnySchema[T]) stripped() bool {
+	return s.strip
+}
+
+// references returns the references used as rule arguments or When
+// conditions.
+func (s *AnySchema[T]) references() []Reference {
+	var refs []Reference
+	for _, w := range s.whens {
+		if w.is == nil {
+			refs = append(refs, w.ref)
+		}
+	}
+	return append(refs, ruleReferences(s.rules)...)
+}
+
+func (s *AnySchema[T]) outer() T {
+	return s.self
+}
+
+// When adds a conditional schema. condition is either a reference (a Ref or
+// a key understood by Ref, e.g. "paymentMethod" or "address.country") or a
+// Schema checked against the value itself; the Then/Otherwise (or Switch)
+// schema picked is then applied on top of this schema. A reference to a
+// sibling sees its parsed value, as the sibling is validated first. On an
+// object, an object branch accepts the keys and patterns of the object it
+// doesn't declare itself, and follows its Unknown policy unless it sets one.
+func (s *AnySchema[T]) When(condition any, opts WhenOpts) *AnySchema[T] {
+	c := s.Clone()
+	w := when{opts: opts}
+	switch cond := condition.(type) {
+	case Schema:
+		w.is = cond
+	case Reference:
+		w.ref = cond
+	default:
+		w.ref = Ref(fmt.Sprint(cond))
+	}
+	c.whens = append(slices.Clip(s.whens), w)
+	return c
 }
 
 func (s *AnySchema[T]) Custom(fn func(path string, value any) *ValidationError, msg ...string) *AnySchema[T] {
 	name := string(AnyMsgCustom)
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: name,
 		Msg:  PickSchemaMsg(AnyMsgMap[AnyMsgCustom], msg...),
 		Args: map[string]any{"message": ""},
@@ -64,25 +220,53 @@ func (s *AnySchema[T]) Custom(fn func(path string, value any) *ValidationError,
 			return value, nil
 		},
 	})
-	return s
 }
 
 func (s *AnySchema[T]) Required(msg ...string) *AnySchema[T] {
-	name := string(AnyMsgRequired)
-	s.rules = append(s.rules, Rule{
-		Name: name,
-		Msg:  PickSchemaMsg(AnyMsgMap[AnyMsgRequired], msg...),
-		Args: map[string]any{},
-		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			return value, WhenNil(value, &ValidationError{Path: path, Msg: r.Msg})
-		},
-	})
-	return s
+	return s.withPresence(PresenceRequired, PickSchemaMsg(AnyMsgMap[AnyMsgRequired], msg...))
+}
+
+// Optional allows the value to be missing, overriding the Presence option.
+func (s *AnySchema[T]) Optional() *AnySchema[T] {
+	return s.withPresence(PresenceOptional, "")
+}
+
+// Forbidden requires the value to be missing, e.g. an id on create.
+func (s *AnySchema[T]) Forbidden(msg ...string) *AnySchema[T] {
+	return s.withPresence(PresenceForbidden, PickSchemaMsg(AnyMsgMap[AnyMsgUnknown], msg...))
+}
+
+func (s *AnySchema[T]) withPresence(presence Presence, msg string) *AnySchema[T] {
+	c := s.Clone()
+	c.presence = presence
+	c.presenceMsg = msg
+	return c
+}
+
+// checkPresence returns the presence that applies to the schema and the error
+// for a value breaking it.
+func (s *AnySchema[T]) checkPresence(value any, opts ValidateOptions) (Presence, ValidationErrors) {
+	presence := Coalesce(s.presence, opts.presence, opts.Presence, PresenceOptional)
+	errType := AnyMsgRequired
+	switch {
+	case presence == PresenceRequired && value == nil:
+	case presence == PresenceForbidden && value != nil:
+		errType = AnyMsgUnknown
+	default:
+		return presence, nil
+	}
+	msg := s.presenceMsg
+	if s.presence != presence {
+		msg = AnyMsgMap[errType]
+	}
+	ctx := map[string]any{"label": Coalesce(s.label, opts.path(), "value"), "path": opts.path(), "value": value}
+	opts.report(1)
+	return presence, ValidationErrors{newError(string(errType), msg, ctx, value, opts)}
 }
 
 func (s *AnySchema[T]) Invalid(disallowed []any, msg ...string) *AnySchema[T] {
 	name := string(AnyMsgInvalid)
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: name,
 		Msg:  PickSchemaMsg(AnyMsgMap[AnyMsgInvalid], msg...),
 		Args: map[string]any{"invalid": disallowed},
@@ -97,12 +281,11 @@ func (s *AnySchema[T]) Invalid(disallowed []any, msg ...string) *AnySchema[T] {
 			return value, nil
 		},
 	})
-	return s
 }
 
 func (s *AnySchema[T]) Valid(allowed []any, msg ...string) *AnySchema[T] {
 	name := string(AnyMsgValid)
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: name,
 		Msg:  PickSchemaMsg(AnyMsgMap[AnyMsgValid], msg...),
 		Args: map[string]any{"valid": allowed},
@@ -117,23 +300,73 @@ func (s *AnySchema[T]) Valid(allowed []any, msg ...string) *AnySchema[T] {
 			return value, &ValidationError{Path: path, Msg: r.Msg}
 		},
 	})
-	return s
 }
 
-func (s *AnySchema[T]) Validate(value any) (any, []ValidationError) {
+func (s *AnySchema[T]) Validate(value any) (any, ValidationErrors) {
 	return s.ValidateWithOpts(value, ValidateOptions{})
 }
 
-func (s *AnySchema[T]) ValidateWithOpts(value any, opts ValidateOptions) (any, []ValidationError) {
+func (s *AnySchema[T]) ValidateWithOpts(value any, opts ValidateOptions) (any, ValidationErrors) {
+	top := opts.run == nil
+	opts = opts.begin()
 	if value == nil && s.defaultValue != nil {
-		value = s.defaultValue.value
+		v, err := s.defaultValue.get(opts)
+		if err != nil {
+			ctx := map[string]any{"label": Coalesce(s.label, opts.path(), "value"), "path": opts.path(), "message": err.Error()}
+			opts.report(1)
+			errs := ValidationErrors{newError(string(AnyMsgDefault), AnyMsgMap[AnyMsgDefault], ctx, nil, opts)}
+			if top {
+				errs = opts.truncate(errs)
+			}
+			return nil, errs
+		}
+		value = v
 	}
+	if s.strict {
+		opts.Convert = Ptr(false)
+	}
+	presence, errs := s.checkPresence(value, opts)
+	if errs != nil {
+		if top {
+			errs = opts.truncate(errs)
+		}
+		return value, errs
+	}
+	opts.presence = ""
+
+	if p, ok := any(s.self).(preparer); ok && value != nil {
+		value, errs = p.prepare(value, opts)
+	}
+	val, ruleErrs := RunValidationWithOpts(s.rules, Coalesce(s.label, opts.path(), "value"), value, opts)
+	errs = append(errs, ruleErrs...)
 
-	if opts.Path != nil {
-		s.path = *opts.Path
+	if n, ok := any(s.self).(nested); ok && val != nil && !opts.halted() {
+		var innerErrs ValidationErrors
+		val, innerErrs = n.validateInner(val, opts)
+		errs = append(errs, innerErrs...)
 	}
 
-	return RunValidation(s.rules, Coalesce(s.label, s.path, "value"), s.path, value)
+	for _, w := range s.whens {
+		if opts.halted() {
+			break
+		}
+		if branch := w.branch(val, opts); branch != nil {
+			if b, ok := any(s.self).(brancher); ok {
+				branch = b.whenBranch(branch)
+			}
+			// the branch keeps the presence of the schema unless it sets its own
+			branchOpts := opts
+			branchOpts.presence = presence
+			var branchErrs ValidationErrors
+			val, branchErrs = branch.ValidateWithOpts(val, branchOpts)
+			errs = append(errs, branchErrs...)
+		}
+	}
+
+	if top {
+		errs = opts.truncate(errs)
+	}
+	return val, errs
 }
 
 // --- constructor ---
@@ -1,9 +1,5 @@
 package joi
 
-import (
-	"strconv"
-)
-
 // --- messages ---
 
 type ArrayMsg string
@@ -33,106 +29,122 @@ var _ Schema = (*ArraySchema)(nil)
 
 // --- methods ---
 
+func (s *ArraySchema) Clone() *ArraySchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *ArraySchema) rebase(base *AnySchema[*ArraySchema]) *ArraySchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
 func (s *ArraySchema) Items(schema Schema) *ArraySchema {
-	s.itemsSchema = schema
-	return s
+	c := s.Clone()
+	c.itemsSchema = schema
+	return c
 }
 
-func (s *ArraySchema) Min(limit int, msg ...string) *ArraySchema {
-	s.rules = append(s.rules, Rule{
+func (s *ArraySchema) Min(limit any, msg ...string) *ArraySchema {
+	return s.withRule(Rule{
 		Name: string(ArrayMsgMin),
 		Msg:  PickSchemaMsg(ArrayMsgMap[ArrayMsgMin], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ArrayMsgMin), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			arr, ok := value.([]any)
 			if !ok {
 				return value, nil // base cuida
 			}
-			if len(arr) < r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(arr) < limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *ArraySchema) Max(limit int, msg ...string) *ArraySchema {
-	s.rules = append(s.rules, Rule{
+func (s *ArraySchema) Max(limit any, msg ...string) *ArraySchema {
+	return s.withRule(Rule{
 		Name: string(ArrayMsgMax),
 		Msg:  PickSchemaMsg(ArrayMsgMap[ArrayMsgMax], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ArrayMsgMax), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			arr, ok := value.([]any)
 			if !ok {
 				return value, nil
 			}
-			if len(arr) > r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(arr) > limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *ArraySchema) Length(limit int, msg ...string) *ArraySchema {
-	s.rules = append(s.rules, Rule{
+func (s *ArraySchema) Length(limit any, msg ...string) *ArraySchema {
+	return s.withRule(Rule{
 		Name: string(ArrayMsgLength),
 		Msg:  PickSchemaMsg(ArrayMsgMap[ArrayMsgLength], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ArrayMsgLength), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			arr, ok := value.([]any)
 			if !ok {
 				return value, nil
 			}
-			if len(arr) != r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(arr) != limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *ArraySchema) Validate(value any) (any, []ValidationError) {
-	return s.ValidateWithOpts(value, ValidateOptions{})
-}
-
-func (s *ArraySchema) ValidateWithOpts(value any, opts ValidateOptions) (any, []ValidationError) {
-	if value == nil && s.defaultValue != nil {
-		value = s.defaultValue.value
-	}
-
-	if opts.Path != nil {
-		s.path = *opts.Path
-	}
-
-	val, errs := RunValidation(s.rules, Coalesce(s.label, s.path, "value"), s.path, value)
-
-	arr, ok := val.([]any)
-	if !ok {
-		return val, errs
+func (s *ArraySchema) validateInner(value any, opts ValidateOptions) (any, ValidationErrors) {
+	arr, ok := value.([]any)
+	if !ok || s.itemsSchema == nil {
+		return value, nil
 	}
 
-	if s.itemsSchema != nil {
-		newArr := make([]any, len(arr))
-		for i, v := range arr {
-			itemPath := s.path + "[" + strconv.Itoa(i) + "]"
-			parsed, itemErrs := s.itemsSchema.ValidateWithOpts(v, ValidateOptions{Path: &itemPath})
-			if len(itemErrs) > 0 {
-				errs = append(errs, itemErrs...)
+	var errs ValidationErrors
+	newArr := make([]any, 0, len(arr))
+	strip := isStripped(s.itemsSchema)
+	opts = opts.enter(arr)
+	for i, v := range arr {
+		if opts.halted() {
+			break
+		}
+		if opts.StripUnknownArrays {
+			parsed, itemErrs := s.itemsSchema.ValidateWithOpts(v, opts.child(i).trial())
+			if len(itemErrs) == 0 && !strip {
+				newArr = append(newArr, parsed)
 			}
-			newArr[i] = parsed
+			continue
+		}
+		parsed, itemErrs := s.itemsSchema.ValidateWithOpts(v, opts.child(i))
+		errs = append(errs, itemErrs...)
+		if !strip {
+			newArr = append(newArr, parsed)
 		}
-		return newArr, errs
 	}
-
-	return arr, errs
+	return newArr, errs
 }
 
 // --- constructor ---
 
+// Array creates an array schema. Any slice or array is accepted and validated
+// as a []any.
 func Array(msg ...string) *ArraySchema {
 	base := Rule{
 		Name: string(ArrayMsgBase),
@@ -141,10 +153,11 @@ func Array(msg ...string) *ArraySchema {
 			if value == nil {
 				return value, nil
 			}
-			if _, ok := value.([]any); !ok {
+			arr, ok := toSlice(value)
+			if !ok {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
-			return value, nil
+			return arr, nil
 		},
 	}
 	s := &ArraySchema{}
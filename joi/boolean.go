@@ -1,5 +1,7 @@
 package joi
 
+import "strings"
+
 // --- messages ---
 
 type BooleanMsg string
@@ -20,6 +22,8 @@ var BooleanMsgMap = map[BooleanMsg]string{
 	BooleanMsgFalsy:  "{{#label}} must be a falsy value",
 }
 
+var booleanStrings = map[string]bool{"true": true, "false": false}
+
 // --- structs ---
 
 type BooleanSchema struct {
@@ -30,8 +34,18 @@ var _ Schema = (*BooleanSchema)(nil)
 
 // --- methods ---
 
+func (s *BooleanSchema) Clone() *BooleanSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *BooleanSchema) rebase(base *AnySchema[*BooleanSchema]) *BooleanSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
 func (s *BooleanSchema) True(msg ...string) *BooleanSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(BooleanMsgTrue),
 		Msg:  PickSchemaMsg(BooleanMsgMap[BooleanMsgTrue], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
@@ -44,12 +58,11 @@ func (s *BooleanSchema) True(msg ...string) *BooleanSchema {
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *BooleanSchema) False(msg ...string) *BooleanSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(BooleanMsgFalse),
 		Msg:  PickSchemaMsg(BooleanMsgMap[BooleanMsgFalse], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
@@ -62,8 +75,7 @@ func (s *BooleanSchema) False(msg ...string) *BooleanSchema {
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *BooleanSchema) Truthy(values ...any) *BooleanSchema {
@@ -72,15 +84,16 @@ func (s *BooleanSchema) Truthy(values ...any) *BooleanSchema {
 		Msg:  PickSchemaMsg(BooleanMsgMap[BooleanMsgTruthy]),
 		Args: map[string]any{"truthy": values},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			if ValueInList(value, r.Args["truthy"].([]any)) {
+			if r.Opts.convert() && ValueInList(value, r.Args["truthy"].([]any)) {
 				return true, nil // convert to bool
 			}
 			return value, nil
 		},
 	}
 	// insert before base
-	s.rules = append([]Rule{rule}, s.rules...)
-	return s
+	c := s.Clone()
+	c.rules = append([]Rule{rule}, c.rules...)
+	return c
 }
 
 func (s *BooleanSchema) Falsy(values ...any) *BooleanSchema {
@@ -89,19 +102,22 @@ func (s *BooleanSchema) Falsy(values ...any) *BooleanSchema {
 		Msg:  PickSchemaMsg(BooleanMsgMap[BooleanMsgFalsy]),
 		Args: map[string]any{"falsy": values},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			if ValueInList(value, r.Args["falsy"].([]any)) {
+			if r.Opts.convert() && ValueInList(value, r.Args["falsy"].([]any)) {
 				return false, nil // convert to bool
 			}
 			return value, nil
 		},
 	}
 	// insert before base
-	s.rules = append([]Rule{rule}, s.rules...)
-	return s
+	c := s.Clone()
+	c.rules = append([]Rule{rule}, c.rules...)
+	return c
 }
 
 // --- constructor ---
 
+// Boolean creates a boolean schema. In convert mode the strings "true" and
+// "false" (any case) are cast, and Truthy/Falsy values are applied.
 func Boolean(msg ...string) *BooleanSchema {
 	s := &BooleanSchema{}
 	s.AnySchema = &AnySchema[*BooleanSchema]{
@@ -114,10 +130,15 @@ func Boolean(msg ...string) *BooleanSchema {
 				if value == nil {
 					return value, nil
 				}
-				if _, ok := value.(bool); !ok {
-					return value, &ValidationError{Path: path, Msg: r.Msg}
+				if _, ok := value.(bool); ok {
+					return value, nil
 				}
-				return value, nil
+				if str, ok := value.(string); ok && r.Opts.convert() {
+					if b, ok := booleanStrings[strings.ToLower(strings.TrimSpace(str))]; ok {
+						return b, nil
+					}
+				}
+				return value, &ValidationError{Path: path, Msg: r.Msg}
 			},
 		}},
 	}
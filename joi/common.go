@@ -1,27 +1,174 @@
 package joi
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
 
 // --- schema ---
 
+// ValidateOptions is the per-call validation context. It carries the caller's
+// preferences and the location being validated, and is passed by value down
+// the schema tree, so schemas never hold per-call state and can be shared
+// between goroutines.
 type ValidateOptions struct {
 	Path *string
+	// AbortEarly stops the validation at the first error.
+	AbortEarly bool
+	// MaxErrors caps the number of errors reported (0 means no limit).
+	MaxErrors int
+	// Convert casts values to the schema type (e.g. "42" to a number) and
+	// lets rules like Trim or Lower transform the value. Defaults to true;
+	// when false only native values are accepted and those rules only check.
+	Convert *bool
+	// Context holds values that can be referenced with Ref("$key").
+	Context map[string]any
+	// Presence applies to the schemas that don't call Required, Optional or
+	// Forbidden themselves. Defaults to PresenceOptional.
+	Presence Presence
+	// AllowUnknown lets objects that don't set Unknown themselves pass
+	// unknown keys through.
+	AllowUnknown bool
+	// StripUnknown removes unknown keys from objects that don't allow them,
+	// instead of reporting them.
+	StripUnknown bool
+	// StripUnknownArrays removes array items that don't match the items
+	// schema, instead of reporting them.
+	StripUnknownArrays bool
+
+	run       *validationRun
+	segments  []any
+	ancestors []any
+	// presence is inherited by a When branch from the schema it belongs to
+	presence Presence
+}
+
+// validationRun is the state shared by every schema taking part in a single
+// validation call.
+type validationRun struct {
+	errors int
+}
+
+// begin makes sure opts belongs to a validation run, starting a new one when
+// called from the top-level ValidateWithOpts.
+func (o ValidateOptions) begin() ValidateOptions {
+	if o.run == nil {
+		o.run = &validationRun{}
+		if o.segments == nil {
+			o.segments = ParsePath(o.path())
+		}
+	}
+	return o
+}
+
+// halted reports whether the run has collected as many errors as allowed.
+func (o ValidateOptions) halted() bool {
+	if o.run == nil {
+		return false
+	}
+	return (o.AbortEarly && o.run.errors > 0) || (o.MaxErrors > 0 && o.run.errors >= o.MaxErrors)
+}
+
+// truncate cuts errs down to the amount allowed by AbortEarly and MaxErrors.
+func (o ValidateOptions) truncate(errs ValidationErrors) ValidationErrors {
+	limit := o.MaxErrors
+	if o.AbortEarly {
+		limit = 1
+	}
+	if limit > 0 && len(errs) > limit {
+		return errs[:limit]
+	}
+	return errs
+}
+
+// trial returns options for a validation whose errors may be discarded (e.g.
+// an alternative that does not match), so they don't count towards the run.
+func (o ValidateOptions) trial() ValidateOptions {
+	o.run = &validationRun{}
+	return o
+}
+
+func (o ValidateOptions) report(n int) {
+	if o.run != nil {
+		o.run.errors += n
+	}
+}
+
+func (o ValidateOptions) path() string {
+	if o.Path == nil {
+		return ""
+	}
+	return *o.Path
+}
+
+func (o ValidateOptions) convert() bool {
+	return o.Convert == nil || *o.Convert
+}
+
+// enter returns the options used to validate the children of container (an
+// object or an array), which becomes their nearest ancestor.
+func (o ValidateOptions) enter(container any) ValidateOptions {
+	o.ancestors = append([]any{container}, o.ancestors...)
+	return o
+}
+
+// child returns the options used to validate the value found at key (a map
+// key or a slice index) below the current path.
+func (o ValidateOptions) child(key any) ValidateOptions {
+	childPath := o.path()
+	switch k := key.(type) {
+	case int:
+		childPath += "[" + strconv.Itoa(k) + "]"
+	default:
+		if childPath != "" {
+			childPath += "."
+		}
+		childPath += fmt.Sprint(k)
+	}
+	o.Path = &childPath
+	o.segments = append(slices.Clip(o.segments), key)
+	return o
 }
 
 type Schema interface {
-	Validate(value any) (any, []ValidationError)
-	ValidateWithOpts(value any, opts ValidateOptions) (any, []ValidationError)
+	Validate(value any) (any, ValidationErrors)
+	ValidateWithOpts(value any, opts ValidateOptions) (any, ValidationErrors)
+}
+
+// nested is implemented by schemas that hold inner schemas (object keys, array
+// items). It runs after the schema's own rules have been applied.
+type nested interface {
+	validateInner(value any, opts ValidateOptions) (any, ValidationErrors)
 }
 
-// --- validation ---
+// preparer is implemented by schemas that rewrite the value before their
+// rules run.
+type preparer interface {
+	prepare(value any, opts ValidateOptions) (any, ValidationErrors)
+}
 
-type ValidationError struct {
-	Path string
-	Msg  string
+// brancher is implemented by schemas that adapt the schema picked by one of
+// their When conditions before it validates their value.
+type brancher interface {
+	whenBranch(branch Schema) Schema
+}
+
+// stripper is implemented by every schema embedding AnySchema; containers
+// leave out of their output the values whose schema is stripped.
+type stripper interface {
+	stripped() bool
+}
+
+func isStripped(s Schema) bool {
+	st, ok := s.(stripper)
+	return ok && st.stripped()
 }
 
-func (e ValidationError) String() string {
-	return fmt.Sprintf("validation error at %q: %s", e.Path, e.Msg)
+// referrer is implemented by every schema embedding AnySchema; objects
+// validate the keys referenced by a sibling before it.
+type referrer interface {
+	references() []Reference
 }
 
 // --- rule ---
@@ -31,4 +178,7 @@ type Rule struct {
 	Args map[string]any
 	Msg  string
 	Fn   func(r Rule, path string, value any) (any, *ValidationError)
+	// Opts holds the options of the running validation. It is set by
+	// RunValidationWithOpts before Fn is called.
+	Opts ValidateOptions
 }
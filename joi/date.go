@@ -31,46 +31,64 @@ var _ Schema = (*DateSchema)(nil)
 
 // --- methods ---
 
-func (s *DateSchema) Min(limit time.Time, msg ...string) *DateSchema {
-	s.rules = append(s.rules, Rule{
+func (s *DateSchema) Clone() *DateSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *DateSchema) rebase(base *AnySchema[*DateSchema]) *DateSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+func (s *DateSchema) Min(limit any, msg ...string) *DateSchema {
+	return s.withRule(Rule{
 		Name: string(DateMsgMin),
 		Msg:  PickSchemaMsg(DateMsgMap[DateMsgMin], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": dateLimit(string(DateMsgMin), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			t, ok := ParseDate(value)
+			t, ok := value.(time.Time)
 			if !ok {
 				return value, nil // base handles error
 			}
-			if t.Before(r.Args["limit"].(time.Time)) {
+			limit, ok := ParseDate(r.Args["limit"])
+			if !ok {
+				return value, argError(path, "limit", "must be a date")
+			}
+			if t.Before(limit) {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return t, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *DateSchema) Max(limit time.Time, msg ...string) *DateSchema {
-	s.rules = append(s.rules, Rule{
+func (s *DateSchema) Max(limit any, msg ...string) *DateSchema {
+	return s.withRule(Rule{
 		Name: string(DateMsgMax),
 		Msg:  PickSchemaMsg(DateMsgMap[DateMsgMax], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": dateLimit(string(DateMsgMax), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			t, ok := ParseDate(value)
+			t, ok := value.(time.Time)
 			if !ok {
 				return value, nil
 			}
-			if t.After(r.Args["limit"].(time.Time)) {
+			limit, ok := ParseDate(r.Args["limit"])
+			if !ok {
+				return value, argError(path, "limit", "must be a date")
+			}
+			if t.After(limit) {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return t, nil
 		},
-	})
-	return s
+	}).self
 }
 
 // --- constructor ---
 
+// Date creates a date schema. time.Time values are accepted as is; in convert
+// mode strings and unix timestamps are cast with ParseDate.
 func Date(msg ...string) *DateSchema {
 	s := &DateSchema{}
 	s.AnySchema = &AnySchema[*DateSchema]{
@@ -83,7 +101,10 @@ func Date(msg ...string) *DateSchema {
 				if value == nil {
 					return value, nil
 				}
-				if t, ok := ParseDate(value); ok {
+				if _, ok := value.(time.Time); ok {
+					return value, nil
+				}
+				if t, ok := ParseDate(value); ok && r.Opts.convert() {
 					return t, nil
 				}
 				return value, &ValidationError{Path: path, Msg: r.Msg}
@@ -0,0 +1,223 @@
+package joi
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// ValidateInto validates input against schema and decodes the parsed value
+// (with conversions and defaults applied) into out, which is usually a
+// struct: object keys are matched with the json names of its fields. Values
+// that don't fit out are reported as any_decode errors at their path. out is
+// left untouched when the validation fails.
+func ValidateInto[T any](schema Schema, input any, out *T) error {
+	return ValidateIntoWithOpts(schema, input, out, ValidateOptions{})
+}
+
+func ValidateIntoWithOpts[T any](schema Schema, input any, out *T, opts ValidateOptions) error {
+	if out == nil {
+		return errors.New("joi: ValidateInto needs a non-nil out")
+	}
+	decoded, errs := validateAs[T](schema, input, opts)
+	if len(errs) > 0 {
+		return errs
+	}
+	*out = decoded
+	return nil
+}
+
+// validateAs validates input against schema and decodes the result into a T.
+func validateAs[T any](schema Schema, input any, opts ValidateOptions) (T, ValidationErrors) {
+	var decoded T
+	parsed, errs := schema.ValidateWithOpts(input, opts)
+	if len(errs) > 0 {
+		return decoded, errs
+	}
+	if errs := decode(parsed, reflect.ValueOf(&decoded).Elem(), ValidateOptions{Path: opts.Path}.begin()); len(errs) > 0 {
+		var zero T
+		return zero, errs
+	}
+	return decoded, nil
+}
+
+// decode sets dst from src, a value produced by a schema.
+func decode(src any, dst reflect.Value, opts ValidateOptions) ValidationErrors {
+	if src == nil {
+		return nil
+	}
+	mismatch := func() ValidationErrors {
+		ctx := map[string]any{"label": "value", "path": opts.path(), "value": src, "type": dst.Type().String()}
+		return ValidationErrors{newError(string(AnyMsgDecode), AnyMsgMap[AnyMsgDecode], ctx, src, opts)}
+	}
+
+	if sv := reflect.ValueOf(src); sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	switch {
+	case dst.Kind() == reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if errs := decode(src, elem.Elem(), opts); len(errs) > 0 {
+			return errs
+		}
+		dst.Set(elem)
+		return nil
+	case dst.Kind() == reflect.Interface:
+		return mismatch()
+	case dst.Type() == timeType:
+		t, ok := ParseDate(src)
+		if !ok {
+			return mismatch()
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := toMap(src)
+		if !ok {
+			return mismatch()
+		}
+		var errs ValidationErrors
+		opts = opts.enter(m)
+		for _, f := range structFields(dst.Type()) {
+			v, exists := m[f.name]
+			if !exists {
+				continue
+			}
+			field, ok := fieldByIndexAlloc(dst, f.index)
+			if !ok {
+				continue
+			}
+			errs = append(errs, decode(v, field, opts.child(f.name))...)
+		}
+		return errs
+	case reflect.Map:
+		m, ok := toMap(src)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		var errs ValidationErrors
+		out := reflect.MakeMapWithSize(dst.Type(), len(m))
+		opts = opts.enter(m)
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			errs = append(errs, decode(m[k], elem, opts.child(k))...)
+			out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(out)
+		return errs
+	case reflect.Slice, reflect.Array:
+		arr, ok := toSlice(src)
+		if !ok || (dst.Kind() == reflect.Array && len(arr) > dst.Len()) {
+			return mismatch()
+		}
+		var errs ValidationErrors
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.MakeSlice(dst.Type(), len(arr), len(arr)))
+		}
+		opts = opts.enter(arr)
+		for i, v := range arr {
+			errs = append(errs, decode(v, dst.Index(i), opts.child(i))...)
+		}
+		return errs
+	case reflect.String:
+		sv := reflect.ValueOf(src)
+		if sv.Kind() != reflect.String {
+			return mismatch()
+		}
+		dst.SetString(sv.String())
+		return nil
+	case reflect.Bool:
+		sv := reflect.ValueOf(src)
+		if sv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		dst.SetBool(sv.Bool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := NormalizeNumber(src)
+		if !ok {
+			return mismatch()
+		}
+		var i int64
+		switch n := n.(type) {
+		case int64:
+			i = n
+		case uint64:
+			if n > math.MaxInt64 {
+				return mismatch()
+			}
+			i = int64(n)
+		case float64:
+			if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+				return mismatch()
+			}
+			i = int64(n)
+		}
+		if dst.OverflowInt(i) {
+			return mismatch()
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := NormalizeNumber(src)
+		if !ok {
+			return mismatch()
+		}
+		var u uint64
+		switch n := n.(type) {
+		case int64:
+			if n < 0 {
+				return mismatch()
+			}
+			u = uint64(n)
+		case uint64:
+			u = n
+		case float64:
+			if n != math.Trunc(n) || n < 0 || n >= math.MaxUint64 {
+				return mismatch()
+			}
+			u = uint64(n)
+		}
+		if dst.OverflowUint(u) {
+			return mismatch()
+		}
+		dst.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := NormalizeNumber(src)
+		if !ok {
+			return mismatch()
+		}
+		f := reflect.ValueOf(n).Convert(reflect.TypeFor[float64]()).Float()
+		if dst.OverflowFloat(f) {
+			return mismatch()
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+	return mismatch()
+}
+
+// fieldByIndexAlloc returns the field of the struct v at index, allocating
+// the embedded struct pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, v.CanSet()
+}
@@ -0,0 +1,115 @@
+package joi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrValidation matches, through errors.Is, every error produced by a schema.
+var ErrValidation = errors.New("validation failed")
+
+// --- validation ---
+
+type ValidationError struct {
+	Path string
+	Msg  string
+	// Type is the name of the rule that failed (e.g. "string_min").
+	Type string
+	// Context holds the values used to render Msg (label, limit, value...).
+	Context map[string]any
+	// Value is the offending value.
+	Value any
+	// Segments is Path split into map keys (string) and slice indexes (int).
+	Segments []any
+}
+
+var _ error = ValidationError{}
+
+func (e ValidationError) String() string {
+	return fmt.Sprintf("validation error at %q: %s", e.Path, e.Msg)
+}
+
+func (e ValidationError) Error() string {
+	return e.String()
+}
+
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// newError builds the error reported by a schema outside of a rule, rendering
+// msg with ctx.
+func newError(errType, msg string, ctx map[string]any, value any, opts ValidateOptions) ValidationError {
+	return ValidationError{
+		Path:     opts.path(),
+		Msg:      RenderTemplate(msg, ctx),
+		Type:     errType,
+		Context:  ctx,
+		Value:    value,
+		Segments: opts.segments,
+	}
+}
+
+// ValidationErrors is the list of errors returned by a schema. It implements
+// error, so it can be returned as is (use Err to get a nil error when empty).
+type ValidationErrors []ValidationError
+
+var _ error = ValidationErrors{}
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Err returns e as an error, or nil when there are no errors.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// First returns the first error, or nil when there are no errors.
+func (e ValidationErrors) First() *ValidationError {
+	if len(e) == 0 {
+		return nil
+	}
+	return &e[0]
+}
+
+// ByPath returns the errors reported at path.
+func (e ValidationErrors) ByPath(path string) ValidationErrors {
+	var out ValidationErrors
+	for _, err := range e {
+		if err.Path == path {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// Has reports whether any error is of the given type (e.g. "string_min").
+func (e ValidationErrors) Has(errType string) bool {
+	for _, err := range e {
+		if err.Type == errType {
+			return true
+		}
+	}
+	return false
+}
@@ -1,5 +1,12 @@
 package joi
 
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+)
+
 // --- messages ---
 
 type NumberMsg string
@@ -22,6 +29,8 @@ var NumberMsgMap = map[NumberMsg]string{
 	NumberMsgNegative: "{{#label}} must be a negative number",
 }
 
+var numericString = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
 // --- structs ---
 
 type NumberSchema struct {
@@ -32,100 +41,121 @@ var _ Schema = (*NumberSchema)(nil)
 
 // --- methods ---
 
-func (s *NumberSchema) Min(limit float64, msg ...string) *NumberSchema {
-	s.rules = append(s.rules, Rule{
+func (s *NumberSchema) Clone() *NumberSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *NumberSchema) rebase(base *AnySchema[*NumberSchema]) *NumberSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+func (s *NumberSchema) Min(limit any, msg ...string) *NumberSchema {
+	return s.withRule(Rule{
 		Name: string(NumberMsgMin),
 		Msg:  PickSchemaMsg(NumberMsgMap[NumberMsgMin], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": numberLimit(string(NumberMsgMin), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			num, ok := value.(float64)
-			if !ok {
+			if _, ok := NormalizeNumber(value); !ok {
 				return value, nil // number_base cuida disso
 			}
-			if num < limit {
+			limit, err := numberArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if cmp, _ := CompareNumbers(value, limit); cmp < 0 {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *NumberSchema) Max(limit float64, msg ...string) *NumberSchema {
-	s.rules = append(s.rules, Rule{
+func (s *NumberSchema) Max(limit any, msg ...string) *NumberSchema {
+	return s.withRule(Rule{
 		Name: string(NumberMsgMax),
 		Msg:  PickSchemaMsg(NumberMsgMap[NumberMsgMax], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": numberLimit(string(NumberMsgMax), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			num, ok := value.(float64)
-			if !ok {
+			if _, ok := NormalizeNumber(value); !ok {
 				return value, nil
 			}
-			if num > limit {
+			limit, err := numberArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if cmp, _ := CompareNumbers(value, limit); cmp > 0 {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *NumberSchema) Integer(msg ...string) *NumberSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(NumberMsgInteger),
 		Msg:  PickSchemaMsg(NumberMsgMap[NumberMsgInteger], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			num, ok := value.(float64)
+			num, ok := NormalizeNumber(value)
 			if !ok {
 				return value, nil
 			}
-			if num != float64(int64(num)) {
+			f, isFloat := num.(float64)
+			if !isFloat {
+				return value, nil
+			}
+			if math.IsInf(f, 0) || f != math.Trunc(f) {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
-			return int64(num), nil
+			if !r.Opts.convert() || f < math.MinInt64 || f >= math.MaxInt64 {
+				return value, nil
+			}
+			return int64(f), nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *NumberSchema) Positive(msg ...string) *NumberSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(NumberMsgPositive),
 		Msg:  PickSchemaMsg(NumberMsgMap[NumberMsgPositive], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			num, ok := value.(float64)
+			cmp, ok := CompareNumbers(value, 0)
 			if !ok {
 				return value, nil
 			}
-			if num <= 0 {
+			if cmp <= 0 {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *NumberSchema) Negative(msg ...string) *NumberSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(NumberMsgNegative),
 		Msg:  PickSchemaMsg(NumberMsgMap[NumberMsgNegative], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
-			num, ok := value.(float64)
+			cmp, ok := CompareNumbers(value, 0)
 			if !ok {
 				return value, nil
 			}
-			if num >= 0 {
+			if cmp >= 0 {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 // --- constructor ---
 
+// Number creates a number schema. Every Go numeric kind is accepted as is;
+// json.Number is converted to int64, uint64 or float64, and so are numeric
+// strings in convert mode.
 func Number(msg ...string) *NumberSchema {
 	s := &NumberSchema{}
 	s.AnySchema = &AnySchema[*NumberSchema]{
@@ -138,9 +168,19 @@ func Number(msg ...string) *NumberSchema {
 				if value == nil {
 					return value, nil
 				}
-				if _, ok := value.(float64); !ok {
+				if str, isString := value.(string); isString && r.Opts.convert() {
+					str = strings.TrimSpace(str)
+					if numericString.MatchString(str) {
+						value = json.Number(str)
+					}
+				}
+				num, ok := NormalizeNumber(value)
+				if !ok {
 					return value, &ValidationError{Path: path, Msg: r.Msg}
 				}
+				if _, isJSON := value.(json.Number); isJSON {
+					return num, nil
+				}
 				return value, nil
 			},
 		}},
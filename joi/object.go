@@ -1,169 +1,673 @@
 package joi
 
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
 // --- messages ---
 
 type ObjectMsg string
 
 var (
-	ObjectMsgBase    ObjectMsg = "object_base"
-	ObjectMsgMin     ObjectMsg = "object_min"
-	ObjectMsgMax     ObjectMsg = "object_max"
-	ObjectMsgLength  ObjectMsg = "object_length"
-	ObjectMsgUnknown ObjectMsg = "object_unknown"
+	ObjectMsgBase           ObjectMsg = "object_base"
+	ObjectMsgMin            ObjectMsg = "object_min"
+	ObjectMsgMax            ObjectMsg = "object_max"
+	ObjectMsgLength         ObjectMsg = "object_length"
+	ObjectMsgUnknown        ObjectMsg = "object_unknown"
+	ObjectMsgAnd            ObjectMsg = "object_and"
+	ObjectMsgMissing        ObjectMsg = "object_missing"
+	ObjectMsgXor            ObjectMsg = "object_xor"
+	ObjectMsgOxor           ObjectMsg = "object_oxor"
+	ObjectMsgNand           ObjectMsg = "object_nand"
+	ObjectMsgWith           ObjectMsg = "object_with"
+	ObjectMsgWithout        ObjectMsg = "object_without"
+	ObjectMsgRenameMultiple ObjectMsg = "object_rename_multiple"
+	ObjectMsgRenameOverride ObjectMsg = "object_rename_override"
 )
 
 var ObjectMsgMap = map[ObjectMsg]string{
-	ObjectMsgBase:    "{{#label}} must be an object",
-	ObjectMsgMin:     "{{#label}} must have at least {{#limit}} keys",
-	ObjectMsgMax:     "{{#label}} must have less than or equal to {{#limit}} keys",
-	ObjectMsgLength:  "{{#label}} must have {{#limit}} keys",
-	ObjectMsgUnknown: "{{#label}} contains unknown key '{{#key}}'",
+	ObjectMsgBase:           "{{#label}} must be an object",
+	ObjectMsgMin:            "{{#label}} must have at least {{#limit}} keys",
+	ObjectMsgMax:            "{{#label}} must have less than or equal to {{#limit}} keys",
+	ObjectMsgLength:         "{{#label}} must have {{#limit}} keys",
+	ObjectMsgUnknown:        "{{#label}} contains unknown key '{{#key}}'",
+	ObjectMsgAnd:            "{{#label}} contains [{{#present}}] without its required peers [{{#missing}}]",
+	ObjectMsgMissing:        "{{#label}} must contain at least one of [{{#peers}}]",
+	ObjectMsgXor:            "{{#label}} contains a conflict between exclusive peers [{{#peers}}]",
+	ObjectMsgOxor:           "{{#label}} contains a conflict between optional exclusive peers [{{#peers}}]",
+	ObjectMsgNand:           "'{{#main}}' must not exist simultaneously with [{{#peers}}]",
+	ObjectMsgWith:           "'{{#main}}' missing required peer '{{#peer}}'",
+	ObjectMsgWithout:        "'{{#main}}' conflict with forbidden peer '{{#peer}}'",
+	ObjectMsgRenameMultiple: "{{#label}} cannot rename '{{#from}}' because multiple renames are disabled and another key was already renamed to '{{#to}}'",
+	ObjectMsgRenameOverride: "{{#label}} cannot rename '{{#from}}' because override is disabled and target '{{#to}}' exists",
 }
 
 // --- structs ---
 
+// ObjectKey is a key declared with Keys; keys are validated in declaration
+// order, except that a key referencing a sibling (see Ref) comes after it.
+type ObjectKey struct {
+	name   string
+	schema Schema
+}
+
+// PatternOptions configures a key pattern added with Pattern.
+type PatternOptions struct {
+	// Fallthrough keeps trying the next patterns after this one matched;
+	// the value parsed by one pattern is handed to the next.
+	Fallthrough bool
+}
+
+type objectPattern struct {
+	regex  *regexp.Regexp
+	key    Schema
+	schema Schema
+	opts   PatternOptions
+}
+
+func (p objectPattern) matches(key string, opts ValidateOptions) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(key)
+	}
+	_, errs := p.key.ValidateWithOpts(key, opts.trial())
+	return len(errs) == 0
+}
+
+// RenameOptions configures a rename added with Rename.
+type RenameOptions struct {
+	// Alias keeps the original key next to the renamed one.
+	Alias bool
+	// Multiple allows renaming several keys to the same target.
+	Multiple bool
+	// Override allows replacing a target key already present in the value.
+	Override bool
+	// IgnoreUndefined skips the rename when the key holds nil.
+	IgnoreUndefined bool
+}
+
+type objectRename struct {
+	from  string
+	regex *regexp.Regexp
+	to    string
+	opts  RenameOptions
+}
+
 type ObjectSchema struct {
 	*AnySchema[*ObjectSchema]
-	fields  map[string]Schema
-	unknown bool
+	keys     []ObjectKey
+	order    []ObjectKey // keys in validation order, see orderKeys
+	fields   map[string]Schema
+	patterns []objectPattern
+	renames  []objectRename
+	// unknown is nil until set with Unknown or StripUnknown, so the
+	// AllowUnknown and StripUnknown options apply.
+	unknown      *bool
+	stripUnknown bool
+	keyPresence  Presence
+	// checks are the rules run on the parsed object, once its keys are
+	// validated (sizes and peer dependencies)
+	checks []Rule
 }
 
 var _ Schema = (*ObjectSchema)(nil)
 
 // --- methods ---
 
+func (s *ObjectSchema) Clone() *ObjectSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *ObjectSchema) rebase(base *AnySchema[*ObjectSchema]) *ObjectSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+// Keys adds keys to the object, in the given order. A key that is already
+// declared keeps its position and gets the new schema.
+func (s *ObjectSchema) Keys(keys ...ObjectKey) *ObjectSchema {
+	c := s.Clone()
+	c.keys = slices.Clone(s.keys)
+	c.fields = maps.Clone(s.fields)
+	for _, k := range keys {
+		if _, exists := c.fields[k.name]; exists {
+			i := slices.IndexFunc(c.keys, func(o ObjectKey) bool { return o.name == k.name })
+			c.keys[i] = k
+		} else {
+			c.keys = append(c.keys, k)
+		}
+		c.fields[k.name] = k.schema
+	}
+	c.order = orderKeys(c.keys)
+	return c
+}
+
+// Pattern validates the keys not declared with Keys that match key, which is
+// a *regexp.Regexp, a regex string or a Schema the key must pass, against
+// schema (nil accepts any value). Patterns are tried in order and the first
+// match wins unless it sets Fallthrough. Keys matching no pattern follow the
+// Unknown policy, so by default they fail with object_unknown.
+func (s *ObjectSchema) Pattern(key any, schema Schema, opts ...PatternOptions) *ObjectSchema {
+	p := objectPattern{schema: schema}
+	switch k := key.(type) {
+	case *regexp.Regexp:
+		p.regex = k
+	case string:
+		p.regex = regexp.MustCompile(k)
+	case Schema:
+		p.key = k
+	default:
+		panic(fmt.Sprintf("joi: Pattern key must be a *regexp.Regexp, string or Schema, got %T", key))
+	}
+	if len(opts) > 0 {
+		p.opts = opts[0]
+	}
+	c := s.Clone()
+	c.patterns = slices.Concat(s.patterns, []objectPattern{p})
+	return c
+}
+
+// Presence sets the presence of the keys (and the keys of nested objects)
+// that don't call Required, Optional or Forbidden themselves, overriding the
+// Presence option for this object.
+func (s *ObjectSchema) Presence(presence Presence) *ObjectSchema {
+	c := s.Clone()
+	c.keyPresence = presence
+	return c
+}
+
+// Rename moves the key from, a key name or a *regexp.Regexp matched against
+// every key, to the key to before the rules and keys of the object are
+// validated. With a regex, to is a template expanded with the submatches of
+// each key (e.g. "$1").
+func (s *ObjectSchema) Rename(from any, to string, opts ...RenameOptions) *ObjectSchema {
+	r := objectRename{to: to}
+	switch f := from.(type) {
+	case string:
+		r.from = f
+	case *regexp.Regexp:
+		r.regex = f
+	default:
+		panic(fmt.Sprintf("joi: Rename from must be a string or *regexp.Regexp, got %T", from))
+	}
+	if len(opts) > 0 {
+		r.opts = opts[0]
+	}
+	c := s.Clone()
+	c.renames = slices.Concat(s.renames, []objectRename{r})
+	return c
+}
+
+// whenBranch lets an object branch of When add to this object: the keys and
+// patterns of s missing in the branch, already validated by s, are accepted
+// as they are, and the branch inherits the Unknown policy of s unless it sets
+// its own.
+func (s *ObjectSchema) whenBranch(branch Schema) Schema {
+	b, ok := branch.(Builder[*ObjectSchema])
+	if !ok {
+		return branch
+	}
+	o := b.outer()
+	var keys []ObjectKey
+	for _, k := range s.keys {
+		if _, declared := o.fields[k.name]; !declared {
+			keys = append(keys, Key(k.name, Any[Schema]().Optional()))
+		}
+	}
+	c := o.Keys(keys...)
+	c.patterns = slices.Clone(o.patterns)
+	for _, p := range s.patterns {
+		c.patterns = append(c.patterns, objectPattern{regex: p.regex, key: p.key})
+	}
+	if c.unknown == nil {
+		c.unknown, c.stripUnknown = s.unknown, s.stripUnknown
+	}
+	return c
+}
+
+// withCheck adds a rule run on the parsed object, so it sees the keys after
+// renames, defaults and stripping.
+func (s *ObjectSchema) withCheck(r Rule) *ObjectSchema {
+	c := s.Clone()
+	c.checks = slices.Concat(s.checks, []Rule{r})
+	return c
+}
+
+func (s *ObjectSchema) references() []Reference {
+	return append(s.AnySchema.references(), ruleReferences(s.checks)...)
+}
+
 func (s *ObjectSchema) Unknown(allow bool) *ObjectSchema {
-	s.unknown = allow
-	return s
+	c := s.Clone()
+	c.unknown = Ptr(allow)
+	c.stripUnknown = false
+	return c
 }
 
-func (s *ObjectSchema) Min(limit int, msg ...string) *ObjectSchema {
-	s.rules = append(s.rules, Rule{
+// StripUnknown removes unknown keys from the output instead of reporting
+// them.
+func (s *ObjectSchema) StripUnknown() *ObjectSchema {
+	c := s.Clone()
+	c.unknown = Ptr(false)
+	c.stripUnknown = true
+	return c
+}
+
+func (s *ObjectSchema) Min(limit any, msg ...string) *ObjectSchema {
+	return s.withCheck(Rule{
 		Name: string(ObjectMsgMin),
 		Msg:  PickSchemaMsg(ObjectMsgMap[ObjectMsgMin], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ObjectMsgMin), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			m, ok := value.(map[string]any)
 			if !ok {
 				return value, nil
 			}
-			if len(m) < r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(m) < limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
 	})
-	return s
 }
 
-func (s *ObjectSchema) Max(limit int, msg ...string) *ObjectSchema {
-	s.rules = append(s.rules, Rule{
+func (s *ObjectSchema) Max(limit any, msg ...string) *ObjectSchema {
+	return s.withCheck(Rule{
 		Name: string(ObjectMsgMax),
 		Msg:  PickSchemaMsg(ObjectMsgMap[ObjectMsgMax], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ObjectMsgMax), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			m, ok := value.(map[string]any)
 			if !ok {
 				return value, nil
 			}
-			if len(m) > r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(m) > limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
 	})
-	return s
 }
 
-func (s *ObjectSchema) Length(limit int, msg ...string) *ObjectSchema {
-	s.rules = append(s.rules, Rule{
+func (s *ObjectSchema) Length(limit any, msg ...string) *ObjectSchema {
+	return s.withCheck(Rule{
 		Name: string(ObjectMsgLength),
 		Msg:  PickSchemaMsg(ObjectMsgMap[ObjectMsgLength], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(ObjectMsgLength), limit)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			m, ok := value.(map[string]any)
 			if !ok {
 				return value, nil
 			}
-			if len(m) != r.Args["limit"].(int) {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if len(m) != limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
 	})
-	return s
 }
 
-func (s *ObjectSchema) Validate(value any) (any, []ValidationError) {
-	return s.ValidateWithOpts(value, ValidateOptions{})
+// And requires that if one of peers is present, all of them are.
+func (s *ObjectSchema) And(peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgAnd, peers, func(r Rule, m map[string]any) *ValidationError {
+		present, missing := splitPresent(m, peers)
+		if len(present) == 0 || len(missing) == 0 {
+			return nil
+		}
+		return peerError(r, missing[0], map[string]any{"present": strings.Join(present, ", "), "missing": strings.Join(missing, ", ")})
+	})
+}
+
+// Or requires at least one of peers to be present.
+func (s *ObjectSchema) Or(peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgMissing, peers, func(r Rule, m map[string]any) *ValidationError {
+		if present, _ := splitPresent(m, peers); len(present) > 0 {
+			return nil
+		}
+		return peerError(r, nil, nil)
+	})
+}
+
+// Xor requires exactly one of peers to be present.
+func (s *ObjectSchema) Xor(peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgXor, peers, func(r Rule, m map[string]any) *ValidationError {
+		present, _ := splitPresent(m, peers)
+		switch len(present) {
+		case 0:
+			err := peerError(r, nil, nil)
+			err.Type, err.Msg = string(ObjectMsgMissing), ObjectMsgMap[ObjectMsgMissing]
+			return err
+		case 1:
+			return nil
+		}
+		return peerError(r, present[1], nil)
+	})
+}
+
+// Oxor allows at most one of peers to be present.
+func (s *ObjectSchema) Oxor(peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgOxor, peers, func(r Rule, m map[string]any) *ValidationError {
+		if present, _ := splitPresent(m, peers); len(present) > 1 {
+			return peerError(r, present[1], nil)
+		}
+		return nil
+	})
+}
+
+// Nand forbids all of peers to be present at the same time.
+func (s *ObjectSchema) Nand(peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgNand, peers, func(r Rule, m map[string]any) *ValidationError {
+		if _, missing := splitPresent(m, peers); len(missing) > 0 || len(peers) == 0 {
+			return nil
+		}
+		return peerError(r, peers[0], map[string]any{"main": peers[0], "peers": strings.Join(peers[1:], ", ")})
+	})
+}
+
+// With requires peers to be present when key is.
+func (s *ObjectSchema) With(key string, peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgWith, peers, func(r Rule, m map[string]any) *ValidationError {
+		if !isPresent(m, key) {
+			return nil
+		}
+		if _, missing := splitPresent(m, peers); len(missing) > 0 {
+			return peerError(r, key, map[string]any{"main": key, "peer": missing[0]})
+		}
+		return nil
+	})
+}
+
+// Without forbids peers to be present when key is.
+func (s *ObjectSchema) Without(key string, peers ...string) *ObjectSchema {
+	return s.peersRule(ObjectMsgWithout, peers, func(r Rule, m map[string]any) *ValidationError {
+		if !isPresent(m, key) {
+			return nil
+		}
+		if present, _ := splitPresent(m, peers); len(present) > 0 {
+			return peerError(r, key, map[string]any{"main": key, "peer": present[0]})
+		}
+		return nil
+	})
+}
+
+func (s *ObjectSchema) peersRule(name ObjectMsg, peers []string, check func(r Rule, m map[string]any) *ValidationError) *ObjectSchema {
+	return s.withCheck(Rule{
+		Name: string(name),
+		Msg:  ObjectMsgMap[name],
+		Args: map[string]any{"peers": strings.Join(peers, ", ")},
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			m, ok := value.(map[string]any)
+			if !ok {
+				return value, nil
+			}
+			return value, check(r, m)
+		},
+	})
 }
 
-func (s *ObjectSchema) ValidateWithOpts(value any, opts ValidateOptions) (any, []ValidationError) {
-	var path string
-	if opts.Path != nil {
-		path = *opts.Path
+// peerError builds the error of a peers rule, pointing at key (or at the
+// object itself when key is nil).
+func peerError(r Rule, key any, ctx map[string]any) *ValidationError {
+	opts := r.Opts
+	if key != nil {
+		opts = opts.child(key)
 	}
+	return &ValidationError{Path: opts.path(), Msg: r.Msg, Context: ctx, Segments: opts.segments}
+}
 
-	val, errs := RunValidation(s.rules, Coalesce(path, "value"), path, value)
+func isPresent(m map[string]any, key string) bool {
+	return m[key] != nil
+}
 
-	if val == nil {
-		return nil, errs
+func splitPresent(m map[string]any, keys []string) (present, missing []string) {
+	for _, k := range keys {
+		if isPresent(m, k) {
+			present = append(present, k)
+		} else {
+			missing = append(missing, k)
+		}
 	}
+	return present, missing
+}
 
-	m, ok := val.(map[string]any)
+func (s *ObjectSchema) validateInner(value any, opts ValidateOptions) (any, ValidationErrors) {
+	m, ok := value.(map[string]any)
 	if !ok {
-		return val, errs
+		return value, nil
 	}
 
-	var childErrs []ValidationError
+	var errs ValidationErrors
 	parsed := make(map[string]any)
+	objOpts := opts
+	// the parent seen by references, conditions and default functions: each
+	// key is replaced by its parsed value once validated
+	current := maps.Clone(m)
+	opts = opts.enter(current)
+	if s.keyPresence != "" {
+		opts.Presence = s.keyPresence
+	}
 
-	for k, schema := range s.fields {
+	for _, key := range s.order {
+		if opts.halted() {
+			break
+		}
+		k, schema := key.name, key.schema
 		if v, exists := m[k]; exists {
 			// valida campo existente
-			childPath := path + "." + k
-			parsedVal, ce := schema.ValidateWithOpts(v, ValidateOptions{Path: &childPath})
-			if len(ce) > 0 {
-				childErrs = append(childErrs, ce...)
+			parsedVal, ce := schema.ValidateWithOpts(v, opts.child(k))
+			errs = append(errs, ce...)
+			current[k] = parsedVal
+			if !isStripped(schema) {
+				parsed[k] = parsedVal
 			}
-			parsed[k] = parsedVal
 		} else {
-			// campo ausente → valida contra nil (pra Required() funcionar)
-			childPath := path + "." + k
-			_, ce := schema.ValidateWithOpts(nil, ValidateOptions{Path: &childPath})
-			if len(ce) > 0 {
-				childErrs = append(childErrs, ce...)
+			// campo ausente → valida contra nil (pra Required() e Default() funcionarem)
+			parsedVal, ce := schema.ValidateWithOpts(nil, opts.child(k))
+			errs = append(errs, ce...)
+			if parsedVal != nil {
+				current[k] = parsedVal
+			}
+			if parsedVal != nil && !isStripped(schema) {
+				parsed[k] = parsedVal
 			}
 		}
 	}
 
-	if !s.unknown {
-		for k := range m {
-			if _, ok := s.fields[k]; !ok {
-				childErrs = append(childErrs, ValidationError{
-					Path: path + "." + k,
-					Msg:  RenderTemplate(ObjectMsgMap[ObjectMsgUnknown], map[string]any{"label": path, "key": k}),
-				})
-			}
+	label := Coalesce(s.label, opts.path(), "value")
+	allowUnknown := opts.AllowUnknown
+	if s.unknown != nil {
+		allowUnknown = *s.unknown
+	}
+	stripUnknown := s.stripUnknown || opts.StripUnknown
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		if _, ok := s.fields[k]; ok {
+			continue
 		}
-	} else {
-		for k, v := range m {
-			if _, ok := s.fields[k]; !ok {
+		if opts.halted() {
+			break
+		}
+		v, matched, strip, ce := s.matchPatterns(k, m[k], opts)
+		errs = append(errs, ce...)
+		current[k] = v
+		if matched || allowUnknown {
+			if !strip {
 				parsed[k] = v
 			}
+			continue
+		}
+		if stripUnknown {
+			continue
 		}
+		ctx := map[string]any{"label": label, "key": k, "value": v}
+		opts.report(1)
+		errs = append(errs, newError(string(ObjectMsgUnknown), ObjectMsgMap[ObjectMsgUnknown], ctx, v, opts.child(k)))
 	}
 
-	if len(childErrs) > 0 {
-		errs = append(errs, childErrs...)
+	if len(s.checks) > 0 && !opts.halted() {
+		_, ce := RunValidationWithOpts(s.checks, label, parsed, objOpts)
+		errs = append(errs, ce...)
 	}
 	return parsed, errs
 }
 
+func (s *ObjectSchema) matchPatterns(key string, value any, opts ValidateOptions) (any, bool, bool, ValidationErrors) {
+	var errs ValidationErrors
+	matched, strip := false, false
+	for _, p := range s.patterns {
+		if !p.matches(key, opts) {
+			continue
+		}
+		matched = true
+		if p.schema != nil {
+			var ce ValidationErrors
+			value, ce = p.schema.ValidateWithOpts(value, opts.child(key))
+			errs = append(errs, ce...)
+			strip = strip || isStripped(p.schema)
+		}
+		if !p.opts.Fallthrough || opts.halted() {
+			break
+		}
+	}
+	return value, matched, strip, errs
+}
+
+// orderKeys returns keys in declaration order, moving each key after the
+// siblings its rules reference so it sees their parsed values. Keys in a
+// reference cycle keep their order.
+func orderKeys(keys []ObjectKey) []ObjectKey {
+	declared := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		declared[k.name] = true
+	}
+	deps := make([][]string, len(keys))
+	for i, k := range keys {
+		r, ok := k.schema.(referrer)
+		if !ok {
+			continue
+		}
+		for _, ref := range r.references() {
+			if name, ok := ref.sibling(); ok && name != k.name && declared[name] {
+				deps[i] = append(deps[i], name)
+			}
+		}
+	}
+	done := make(map[string]bool, len(keys))
+	pending := func(name string) bool { return !done[name] }
+	ordered := make([]ObjectKey, 0, len(keys))
+	for len(ordered) < len(keys) {
+		next := -1
+		for i, k := range keys {
+			if done[k.name] {
+				continue
+			}
+			if next < 0 {
+				next = i // first pending key, taken if a cycle blocks them all
+			}
+			if !slices.ContainsFunc(deps[i], pending) {
+				next = i
+				break
+			}
+		}
+		done[keys[next].name] = true
+		ordered = append(ordered, keys[next])
+	}
+	return ordered
+}
+
+// prepare applies the renames, so the rules of the object see the new keys.
+func (s *ObjectSchema) prepare(value any, opts ValidateOptions) (any, ValidationErrors) {
+	if len(s.renames) == 0 {
+		return value, nil
+	}
+	m, ok := toMap(value)
+	if !ok {
+		return value, nil
+	}
+	return s.applyRenames(m, opts)
+}
+
+// applyRenames returns a copy of m with the renames applied, in the order
+// they were declared.
+func (s *ObjectSchema) applyRenames(m map[string]any, opts ValidateOptions) (map[string]any, ValidationErrors) {
+	var errs ValidationErrors
+	out := maps.Clone(m)
+	renamed := map[string]bool{}
+	label := Coalesce(s.label, opts.path(), "value")
+	for _, r := range s.renames {
+		var sources []string
+		if r.regex != nil {
+			for _, k := range slices.Sorted(maps.Keys(out)) {
+				if r.regex.MatchString(k) && !renamed[k] {
+					sources = append(sources, k)
+				}
+			}
+		} else if _, ok := out[r.from]; ok {
+			sources = []string{r.from}
+		}
+		for _, from := range sources {
+			if opts.halted() {
+				return out, errs
+			}
+			to := r.to
+			if r.regex != nil {
+				to = string(r.regex.ExpandString(nil, r.to, from, r.regex.FindStringSubmatchIndex(from)))
+			}
+			v := out[from]
+			if v == nil && r.opts.IgnoreUndefined || from == to {
+				continue
+			}
+			errType := ""
+			if renamed[to] && !r.opts.Multiple {
+				errType = string(ObjectMsgRenameMultiple)
+			} else if _, exists := out[to]; exists && !renamed[to] && !r.opts.Override {
+				errType = string(ObjectMsgRenameOverride)
+			}
+			if errType != "" {
+				ctx := map[string]any{"label": label, "from": from, "to": to, "value": v}
+				opts.report(1)
+				errs = append(errs, newError(errType, ObjectMsgMap[ObjectMsg(errType)], ctx, v, opts.child(from)))
+				continue
+			}
+			out[to] = v
+			renamed[to] = true
+			if !r.opts.Alias {
+				delete(out, from)
+			}
+		}
+	}
+	return out, errs
+}
+
 // --- constructor ---
 
+// Object creates an object schema. Keys given in fields are validated in
+// sorted order (after the siblings they reference); use Keys (with a nil
+// fields map) to control the order.
+// Besides map[string]any, structs (keyed by their json names), pointers to
+// structs and maps with string keys are accepted and validated as maps.
 func Object(fields map[string]Schema, msg ...string) *ObjectSchema {
-	s := &ObjectSchema{fields: fields, unknown: false}
+	s := &ObjectSchema{fields: map[string]Schema{}}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		s.keys = append(s.keys, Key(name, fields[name]))
+		s.fields[name] = fields[name]
+	}
+	s.order = orderKeys(s.keys)
 	s.AnySchema = &AnySchema[*ObjectSchema]{
 		self:  s,
 		label: "value",
@@ -174,12 +678,17 @@ func Object(fields map[string]Schema, msg ...string) *ObjectSchema {
 				if value == nil {
 					return value, nil
 				}
-				if _, ok := value.(map[string]any); !ok {
+				m, ok := toMap(value)
+				if !ok {
 					return value, &ValidationError{Path: path, Msg: r.Msg}
 				}
-				return value, nil
+				return m, nil
 			},
 		}},
 	}
 	return s
 }
+
+func Key(name string, schema Schema) ObjectKey {
+	return ObjectKey{name: name, schema: schema}
+}
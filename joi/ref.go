@@ -0,0 +1,209 @@
+package joi
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Reference points to another value of the validated input, or of
+// ValidateOptions.Context, and can be used wherever a rule accepts a limit or
+// a list of values. It is resolved on every validation.
+type Reference struct {
+	key      string
+	ancestor int
+	context  bool
+	root     bool
+	path     []any
+}
+
+// Ref creates a reference following joi's syntax:
+//   - "a.b" is the key b of the sibling a;
+//   - leading dots go up the tree: ".a" is the key a of the value itself,
+//     "..a" is a sibling (same as "a"), "...a" a key of the grandparent;
+//   - "/a" starts from the root value;
+//   - "$a.b" reads ValidateOptions.Context.
+func Ref(key string) Reference {
+	ref := Reference{key: key, ancestor: 1}
+	rest := key
+	switch {
+	case strings.HasPrefix(rest, "$"):
+		ref.context = true
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "/"):
+		ref.root = true
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "."):
+		dots := len(rest) - len(strings.TrimLeft(rest, "."))
+		ref.ancestor = dots - 1
+		rest = rest[dots:]
+	}
+	ref.path = ParsePath(rest)
+	return ref
+}
+
+func (r Reference) String() string {
+	return r.key
+}
+
+// sibling returns the key of the sibling the reference starts from, if any.
+func (r Reference) sibling() (string, bool) {
+	if r.context || r.root || r.ancestor != 1 || len(r.path) == 0 {
+		return "", false
+	}
+	key, ok := r.path[0].(string)
+	return key, ok
+}
+
+// resolve returns the referenced value; value is the one being validated,
+// used by references to the value itself.
+func (r Reference) resolve(value any, opts ValidateOptions) (any, bool) {
+	var base any
+	switch {
+	case r.context:
+		base = opts.Context
+	case r.root:
+		if len(opts.ancestors) == 0 {
+			return nil, false
+		}
+		base = opts.ancestors[len(opts.ancestors)-1]
+	case r.ancestor == 0:
+		base = value
+	case r.ancestor <= len(opts.ancestors):
+		base = opts.ancestors[r.ancestor-1]
+	default:
+		return nil, false
+	}
+	return Reach(base, r.path)
+}
+
+// resolveArgs returns a copy of args with every Reference (including the ones
+// inside []any lists) replaced by the value it points to.
+func resolveArgs(args map[string]any, value any, opts ValidateOptions) map[string]any {
+	var resolved map[string]any
+	set := func(name string, v any) {
+		if resolved == nil {
+			resolved = maps.Clone(args)
+		}
+		resolved[name] = v
+	}
+	for name, arg := range args {
+		switch a := arg.(type) {
+		case Reference:
+			v, _ := a.resolve(value, opts)
+			set(name, v)
+		case []any:
+			if !slices.ContainsFunc(a, isReference) {
+				continue
+			}
+			list := make([]any, len(a))
+			for i, item := range a {
+				list[i] = item
+				if ref, ok := item.(Reference); ok {
+					list[i], _ = ref.resolve(value, opts)
+				}
+			}
+			set(name, list)
+		}
+	}
+	if resolved == nil {
+		return args
+	}
+	return resolved
+}
+
+// ruleReferences returns the references used as arguments of rules.
+func ruleReferences(rules []Rule) []Reference {
+	var refs []Reference
+	for _, r := range rules {
+		for _, arg := range r.Args {
+			switch a := arg.(type) {
+			case Reference:
+				refs = append(refs, a)
+			case []any:
+				for _, item := range a {
+					if ref, ok := item.(Reference); ok {
+						refs = append(refs, ref)
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+func isReference(v any) bool {
+	_, ok := v.(Reference)
+	return ok
+}
+
+// argError reports a rule argument (usually a resolved reference) that the rule
+// can't use.
+func argError(path, arg, reason string) *ValidationError {
+	return &ValidationError{
+		Path:    path,
+		Msg:     AnyMsgMap[AnyMsgRef],
+		Type:    string(AnyMsgRef),
+		Context: map[string]any{"arg": arg, "reason": reason},
+	}
+}
+
+func intArg(r Rule, path, name string) (int, *ValidationError) {
+	if n, ok := toInt(r.Args[name]); ok {
+		return n, nil
+	}
+	return 0, argError(path, name, "must be a non-negative integer")
+}
+
+func numberArg(r Rule, path, name string) (any, *ValidationError) {
+	if n, ok := NormalizeNumber(r.Args[name]); ok {
+		return n, nil
+	}
+	return nil, argError(path, name, "must be a number")
+}
+
+// toInt returns v as an int when it is a non-negative integer.
+func toInt(v any) (int, bool) {
+	switch n, _ := NormalizeNumber(v); n := n.(type) {
+	case int64:
+		if n >= 0 && n <= math.MaxInt {
+			return int(n), true
+		}
+	case uint64:
+		if n <= math.MaxInt {
+			return int(n), true
+		}
+	case float64:
+		// MaxInt+1 is exact as a float64, unlike MaxInt
+		if n >= 0 && n == math.Trunc(n) && n < math.MaxInt+1 {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// intLimit, numberLimit and dateLimit check the limit given to a rule when it
+// is built: a literal of the wrong kind panics, so only a Reference can turn
+// into an any_ref error during validation.
+func intLimit(rule string, limit any) any {
+	if _, ok := toInt(limit); !ok && !isReference(limit) {
+		panic(fmt.Sprintf("joi: %s limit must be a non-negative integer or a Reference, got %#v", rule, limit))
+	}
+	return limit
+}
+
+func numberLimit(rule string, limit any) any {
+	if _, ok := NormalizeNumber(limit); !ok && !isReference(limit) {
+		panic(fmt.Sprintf("joi: %s limit must be a number or a Reference, got %#v", rule, limit))
+	}
+	return limit
+}
+
+func dateLimit(rule string, limit any) any {
+	if _, ok := ParseDate(limit); !ok && !isReference(limit) {
+		panic(fmt.Sprintf("joi: %s limit must be a date or a Reference, got %#v", rule, limit))
+	}
+	return limit
+}
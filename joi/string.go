@@ -1,9 +1,20 @@
 package joi
 
 import (
+	"fmt"
+	"maps"
 	"net/mail"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
 )
 
 // --- messages ---
@@ -11,98 +22,303 @@ import (
 type StringMsg string
 
 var (
-	StringMsgBase   StringMsg = "string_base"
-	StringMsgMin    StringMsg = "string_min"
-	StringMsgMax    StringMsg = "string_max"
-	StringMsgLength StringMsg = "string_length"
-	StringMsgRegex  StringMsg = "string_regex"
-	StringMsgTrim   StringMsg = "string_trim"
-	StringMsgLower  StringMsg = "string_lower"
-	StringMsgUpper  StringMsg = "string_upper"
-	StringMsgEmail  StringMsg = "string.email"
+	StringMsgBase        StringMsg = "string_base"
+	StringMsgMin         StringMsg = "string_min"
+	StringMsgMax         StringMsg = "string_max"
+	StringMsgLength      StringMsg = "string_length"
+	StringMsgRegex       StringMsg = "string_regex"
+	StringMsgTrim        StringMsg = "string_trim"
+	StringMsgLower       StringMsg = "string_lower"
+	StringMsgUpper       StringMsg = "string_upper"
+	StringMsgReplace     StringMsg = "string_replace"
+	StringMsgEmail       StringMsg = "string.email"
+	StringMsgEmailLocal  StringMsg = "string_email_local"
+	StringMsgEmailDomain StringMsg = "string_email_domain"
+	StringMsgNormalize   StringMsg = "string_normalize"
+	StringMsgIP          StringMsg = "string_ip"
+	StringMsgIPVersion   StringMsg = "string_ip_version"
+	StringMsgHostname    StringMsg = "string_hostname"
+	StringMsgDomain      StringMsg = "string_domain"
+	StringMsgURI         StringMsg = "string_uri"
+	StringMsgURIScheme   StringMsg = "string_uri_scheme"
+	StringMsgURIRelative StringMsg = "string_uri_relative"
+	StringMsgGUID        StringMsg = "string_guid"
+	StringMsgHex         StringMsg = "string_hex"
+	StringMsgHexAlign    StringMsg = "string_hex_align"
+	StringMsgBase64      StringMsg = "string_base64"
+	StringMsgDataURI     StringMsg = "string_data_uri"
+	StringMsgToken       StringMsg = "string_token"
+	StringMsgAlphanum    StringMsg = "string_alphanum"
 )
 
 var StringMsgMap = map[StringMsg]string{
-	StringMsgBase:   "{{#label}} must be a string",
-	StringMsgMin:    "{{#label}} length must be at least {{#limit}} characters long",
-	StringMsgMax:    "{{#label}} length must be less than or equal to {{#limit}} characters long",
-	StringMsgLength: "{{#label}} length must be {{#limit}} characters long",
-	StringMsgRegex:  "{{#label}} with value {{#value}} fails to match the required pattern",
-	StringMsgTrim:   "{{#label}} must be a trimmed string",
-	StringMsgLower:  "{{#label}} must be a lowercase string",
-	StringMsgUpper:  "{{#label}} must be an uppercase string",
-	StringMsgEmail:  "{{#label}} must be a valid email",
+	StringMsgBase:        "{{#label}} must be a string",
+	StringMsgMin:         "{{#label}} length must be at least {{#limit}} characters long",
+	StringMsgMax:         "{{#label}} length must be less than or equal to {{#limit}} characters long",
+	StringMsgLength:      "{{#label}} length must be {{#limit}} characters long",
+	StringMsgRegex:       "{{#label}} with value {{#value}} fails to match the required pattern",
+	StringMsgTrim:        "{{#label}} must be a trimmed string",
+	StringMsgLower:       "{{#label}} must be a lowercase string",
+	StringMsgUpper:       "{{#label}} must be an uppercase string",
+	StringMsgReplace:     "{{#label}} must not contain matches of {{#pattern}}",
+	StringMsgEmail:       "{{#label}} must be a valid email",
+	StringMsgEmailLocal:  "{{#label}} must be a valid email, '{{#email}}' has an invalid local part",
+	StringMsgEmailDomain: "{{#label}} must be a valid email, '{{#email}}' has an invalid domain",
+	StringMsgNormalize:   "{{#label}} must be unicode normalized in the {{#form}} form",
+	StringMsgIP:          "{{#label}} must be a valid ip address with a {{#cidr}} CIDR",
+	StringMsgIPVersion:   "{{#label}} must be a valid ip address of one of the following versions [{{#version}}] with a {{#cidr}} CIDR",
+	StringMsgHostname:    "{{#label}} must be a valid hostname",
+	StringMsgDomain:      "{{#label}} must contain a valid domain name",
+	StringMsgURI:         "{{#label}} must be a valid uri",
+	StringMsgURIScheme:   "{{#label}} must be a valid uri with a scheme matching the [{{#scheme}}] pattern",
+	StringMsgURIRelative: "{{#label}} must be a valid relative uri",
+	StringMsgGUID:        "{{#label}} must be a valid GUID",
+	StringMsgHex:         "{{#label}} must only contain hexadecimal characters",
+	StringMsgHexAlign:    "{{#label}} hex decoded representation must be byte aligned",
+	StringMsgBase64:      "{{#label}} must be a valid base64 string",
+	StringMsgDataURI:     "{{#label}} must be a valid dataUri string",
+	StringMsgToken:       "{{#label}} must only contain alpha-numeric and underscore characters",
+	StringMsgAlphanum:    "{{#label}} must only contain alpha-numeric characters",
 }
 
 // --- structs ---
 
+// StringEncoding is how Min, Max and Length count the length of a string.
+type StringEncoding string
+
+const (
+	// EncodingRunes counts unicode code points: "João" has 4.
+	EncodingRunes StringEncoding = "runes"
+	// EncodingGraphemes counts user-perceived characters: "👍🏽" has 1.
+	EncodingGraphemes StringEncoding = "graphemes"
+	// EncodingBytes counts UTF-8 bytes: "João" has 5.
+	EncodingBytes StringEncoding = "bytes"
+)
+
+// StringCase is the letter case enforced by Case.
+type StringCase string
+
+const (
+	CaseLower StringCase = "lower"
+	CaseUpper StringCase = "upper"
+)
+
+// NormalizationForm is a unicode normalization form used by Normalize.
+type NormalizationForm string
+
+const (
+	NFC  NormalizationForm = "NFC"
+	NFD  NormalizationForm = "NFD"
+	NFKC NormalizationForm = "NFKC"
+	NFKD NormalizationForm = "NFKD"
+)
+
+var normForms = map[NormalizationForm]norm.Form{NFC: norm.NFC, NFD: norm.NFD, NFKC: norm.NFKC, NFKD: norm.NFKD}
+
+// IPVersion is an IP address version accepted by IP.
+type IPVersion string
+
+const (
+	IPv4 IPVersion = "ipv4"
+	IPv6 IPVersion = "ipv6"
+)
+
+// IPOptions configures the IP rule.
+type IPOptions struct {
+	// Version lists the accepted versions; any version when empty.
+	Version []IPVersion
+	// CIDR tells whether a prefix length ("10.0.0.0/8") must, may or must
+	// not follow the address. Defaults to PresenceOptional.
+	CIDR Presence
+}
+
+// DomainOptions configures the Domain rule.
+type DomainOptions struct {
+	// MinDomainSegments is the minimum number of labels; defaults to 2.
+	MinDomainSegments int
+	// MaxDomainSegments is the maximum number of labels (0 means no limit).
+	MaxDomainSegments int
+	// AllowTLDs, when set, lists the only top-level domains accepted.
+	AllowTLDs []string
+	// DenyTLDs lists top-level domains that are rejected.
+	DenyTLDs []string
+}
+
+// URIOptions configures the URI rule.
+type URIOptions struct {
+	// Scheme lists the accepted schemes (e.g. "https"); any when empty.
+	Scheme []string
+	// AllowRelative accepts relative references ("/path?q=1") besides
+	// absolute URIs.
+	AllowRelative bool
+	// RelativeOnly accepts relative references only.
+	RelativeOnly bool
+}
+
+// GUIDOptions configures the GUID rule.
+type GUIDOptions struct {
+	// Version lists the accepted UUID versions (1 to 8); any when empty.
+	Version []int
+}
+
+// HexOptions configures the Hex rule.
+type HexOptions struct {
+	// ByteAligned requires an even number of digits.
+	ByteAligned bool
+	// Lowercase converts the value to lowercase in convert mode; in strict
+	// mode the value must already be lowercase.
+	Lowercase bool
+}
+
+// Base64Options configures the Base64 and DataURI rules.
+type Base64Options struct {
+	// PaddingRequired requires the trailing "=" padding. Defaults to true.
+	PaddingRequired *bool
+	// URLSafe uses the URL-safe alphabet ("-" and "_" instead of "+" and
+	// "/").
+	URLSafe bool
+}
+
+// EmailOptions configures the Email rule.
+type EmailOptions struct {
+	// AllowDisplayName accepts addresses like "John Doe <john@example.com>".
+	AllowDisplayName bool
+	// Multiple accepts a list of addresses separated by Separator.
+	Multiple bool
+	// Separator splits the addresses when Multiple is set. Defaults to ",".
+	Separator string
+	// MinDomainSegments is the minimum number of domain labels; defaults to
+	// 2, so "user@localhost" is rejected.
+	MinDomainSegments int
+	// AllowTLDs, when set, lists the only top-level domains accepted.
+	AllowTLDs []string
+	// DenyTLDs lists top-level domains that are rejected.
+	DenyTLDs []string
+	// AllowUnicode accepts non-ASCII characters in the local part and
+	// internationalized domain names. Defaults to true.
+	AllowUnicode *bool
+	// IgnoreLength skips the limits of 254 characters for the address and 64
+	// for its local part.
+	IgnoreLength bool
+}
+
 type StringSchema struct {
 	*AnySchema[*StringSchema]
+	encoding StringEncoding
+	truncate bool
 }
 
 var _ Schema = (*StringSchema)(nil)
 
 // --- methods ---
 
-func (s *StringSchema) Min(limit int, msg ...string) *StringSchema {
-	s.rules = append(s.rules, Rule{
+func (s *StringSchema) Clone() *StringSchema {
+	return s.AnySchema.Clone().self
+}
+
+func (s *StringSchema) rebase(base *AnySchema[*StringSchema]) *StringSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+// Encoding sets how the Min, Max and Length rules added after it count the
+// length of the value (EncodingRunes by default), so a schema can mix them:
+//
+//	String().Encoding(EncodingBytes).Max(255).Encoding(EncodingGraphemes).Min(1)
+func (s *StringSchema) Encoding(encoding StringEncoding) *StringSchema {
+	c := s.Clone()
+	c.encoding = encoding
+	return c
+}
+
+func (s *StringSchema) Min(limit any, msg ...string) *StringSchema {
+	return s.withRule(Rule{
 		Name: string(StringMsgMin),
 		Msg:  PickSchemaMsg(StringMsgMap[StringMsgMin], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(StringMsgMin), limit), "encoding": Coalesce(s.encoding, EncodingRunes)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			if len(str) < limit {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if stringLength(str, r.Args["encoding"]) < limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *StringSchema) Max(limit int, msg ...string) *StringSchema {
-	s.rules = append(s.rules, Rule{
+func (s *StringSchema) Max(limit any, msg ...string) *StringSchema {
+	return s.withRule(Rule{
 		Name: string(StringMsgMax),
 		Msg:  PickSchemaMsg(StringMsgMap[StringMsgMax], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(StringMsgMax), limit), "encoding": Coalesce(s.encoding, EncodingRunes), "truncate": s.truncate},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			if len(str) > limit {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if stringLength(str, r.Args["encoding"]) > limit {
+				if r.Args["truncate"] == true && r.Opts.convert() {
+					return truncateString(str, limit, r.Args["encoding"]), nil
+				}
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *StringSchema) Length(limit int, msg ...string) *StringSchema {
-	s.rules = append(s.rules, Rule{
+// Truncate makes the Max rules, whether added before or after it, cut the
+// value down to their limit in convert mode instead of failing; in strict mode
+// they still fail.
+func (s *StringSchema) Truncate() *StringSchema {
+	c := s.Clone()
+	c.truncate = true
+	for i, r := range c.rules {
+		if r.Name == string(StringMsgMax) {
+			r.Args = maps.Clone(r.Args)
+			r.Args["truncate"] = true
+			c.rules[i] = r
+		}
+	}
+	return c
+}
+
+func (s *StringSchema) Length(limit any, msg ...string) *StringSchema {
+	return s.withRule(Rule{
 		Name: string(StringMsgLength),
 		Msg:  PickSchemaMsg(StringMsgMap[StringMsgLength], msg...),
-		Args: map[string]any{"limit": limit},
+		Args: map[string]any{"limit": intLimit(string(StringMsgLength), limit), "encoding": Coalesce(s.encoding, EncodingRunes)},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			if len(str) != limit {
+			limit, err := intArg(r, path, "limit")
+			if err != nil {
+				return value, err
+			}
+			if stringLength(str, r.Args["encoding"]) != limit {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
 func (s *StringSchema) Regex(re *regexp.Regexp, msg ...string) *StringSchema {
-	s.rules = append(s.rules, Rule{
+	return s.withRule(Rule{
 		Name: string(StringMsgRegex),
 		Msg:  PickSchemaMsg(StringMsgMap[StringMsgRegex], msg...),
 		Args: map[string]any{"pattern": re.String()},
@@ -116,12 +332,20 @@ func (s *StringSchema) Regex(re *regexp.Regexp, msg ...string) *StringSchema {
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
+// Email requires an email address, checked with the default EmailOptions.
+// Syntax errors are reported as string.email; a bad local part or domain as
+// string_email_local or string_email_domain, with the address in the email
+// context key.
 func (s *StringSchema) Email(msg ...string) *StringSchema {
-	s.rules = append(s.rules, Rule{
+	return s.EmailWithOptions(EmailOptions{}, msg...)
+}
+
+// EmailWithOptions is like Email but checks the address with opts.
+func (s *StringSchema) EmailWithOptions(opts EmailOptions, msg ...string) *StringSchema {
+	return s.withRule(Rule{
 		Name: string(StringMsgEmail),
 		Msg:  PickSchemaMsg(StringMsgMap[StringMsgEmail], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
@@ -133,65 +357,537 @@ func (s *StringSchema) Email(msg ...string) *StringSchema {
 				// Deixa "required" para outra regra; email vazio passa aqui.
 				return value, nil
 			}
-			addr, err := mail.ParseAddress(str)
-			if err != nil || addr.Address != str {
+			emails := []string{str}
+			if opts.Multiple {
+				emails = strings.Split(str, Coalesce(opts.Separator, ","))
+			}
+			for _, email := range emails {
+				if opts.Multiple {
+					email = strings.TrimSpace(email)
+				}
+				if errType := checkEmail(email, opts); errType != "" {
+					err := &ValidationError{Path: path, Msg: r.Msg, Context: map[string]any{"email": email}}
+					if errType != StringMsgEmail {
+						err.Type, err.Msg = string(errType), PickSchemaMsg(StringMsgMap[errType], msg...)
+					}
+					return value, err
+				}
+			}
+			return value, nil
+		},
+	}).self
+}
+
+// IP requires an IPv4 or IPv6 address, optionally with a CIDR prefix.
+func (s *StringSchema) IP(opts IPOptions, msg ...string) *StringSchema {
+	name, versions := StringMsgIP, make([]string, len(opts.Version))
+	for i, v := range opts.Version {
+		versions[i] = string(v)
+	}
+	if len(versions) > 0 {
+		name = StringMsgIPVersion
+	}
+	cidr := Coalesce(opts.CIDR, PresenceOptional)
+	return s.withRule(Rule{
+		Name: string(name),
+		Msg:  PickSchemaMsg(StringMsgMap[name], msg...),
+		Args: map[string]any{"version": strings.Join(versions, ", "), "cidr": string(cidr)},
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			str, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			if !validIP(str, opts.Version, cidr) {
 				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
 			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *StringSchema) Trim() *StringSchema {
-	s.rules = append(s.rules, Rule{
-		Name: string(StringMsgTrim),
-		Msg:  "",
+// Hostname requires a valid hostname (RFC 1123) or IP address.
+func (s *StringSchema) Hostname(msg ...string) *StringSchema {
+	return s.withRule(Rule{
+		Name: string(StringMsgHostname),
+		Msg:  PickSchemaMsg(StringMsgMap[StringMsgHostname], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			return strings.TrimSpace(str), nil
+			if !validHostname(str) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *StringSchema) Lower() *StringSchema {
-	s.rules = append(s.rules, Rule{
-		Name: string(StringMsgLower),
-		Msg:  StringMsgMap[StringMsgLower],
+// Domain requires a domain name such as "example.com".
+func (s *StringSchema) Domain(opts DomainOptions, msg ...string) *StringSchema {
+	return s.withRule(Rule{
+		Name: string(StringMsgDomain),
+		Msg:  PickSchemaMsg(StringMsgMap[StringMsgDomain], msg...),
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			if str != strings.ToLower(str) {
-				return strings.ToLower(str), &ValidationError{Path: path, Msg: r.Msg}
+			if !validDomain(str, opts) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
-			return str, nil
+			return value, nil
 		},
-	})
-	return s
+	}).self
 }
 
-func (s *StringSchema) Upper() *StringSchema {
-	s.rules = append(s.rules, Rule{
-		Name: string(StringMsgUpper),
-		Msg:  StringMsgMap[StringMsgUpper],
+// URI requires an RFC 3986 URI: an absolute one by default, see URIOptions
+// for relative references.
+func (s *StringSchema) URI(opts URIOptions, msg ...string) *StringSchema {
+	return s.withRule(Rule{
+		Name: string(StringMsgURI),
+		Msg:  PickSchemaMsg(StringMsgMap[StringMsgURI], msg...),
+		Args: map[string]any{"scheme": strings.Join(opts.Scheme, ", ")},
 		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
 			str, ok := value.(string)
 			if !ok {
 				return value, nil
 			}
-			if str != strings.ToUpper(str) {
-				return strings.ToUpper(str), &ValidationError{Path: path, Msg: r.Msg}
+			u, err := url.Parse(str)
+			if err != nil || str == "" || !uriChars.MatchString(str) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
 			}
-			return str, nil
+			switch {
+			case opts.RelativeOnly && u.Scheme != "":
+				return value, &ValidationError{Path: path, Type: string(StringMsgURIRelative), Msg: StringMsgMap[StringMsgURIRelative]}
+			case u.Scheme == "" && !opts.RelativeOnly && !opts.AllowRelative:
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			case u.Scheme != "" && len(opts.Scheme) > 0 && !slices.ContainsFunc(opts.Scheme, func(sc string) bool { return strings.EqualFold(sc, u.Scheme) }):
+				return value, &ValidationError{Path: path, Type: string(StringMsgURIScheme), Msg: StringMsgMap[StringMsgURIScheme]}
+			}
+			return value, nil
 		},
-	})
-	return s
+	}).self
+}
+
+// GUID requires a GUID/UUID such as "3f2504e0-4f89-41d3-9a0c-0305e82c3301",
+// optionally wrapped in braces and with ":" or no separators. It panics on a
+// version outside 1 to 8.
+func (s *StringSchema) GUID(opts GUIDOptions, msg ...string) *StringSchema {
+	return s.withRule(patternRule(StringMsgGUID, guidRegex(opts.Version), msg...)).self
+}
+
+// UUID is the same as GUID with any version.
+func (s *StringSchema) UUID(msg ...string) *StringSchema {
+	return s.GUID(GUIDOptions{}, msg...)
+}
+
+// Hex requires hexadecimal digits.
+func (s *StringSchema) Hex(opts HexOptions, msg ...string) *StringSchema {
+	return s.withRule(Rule{
+		Name: string(StringMsgHex),
+		Msg:  PickSchemaMsg(StringMsgMap[StringMsgHex], msg...),
+		Args: map[string]any{"byteAligned": opts.ByteAligned},
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			str, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			if !hexChars.MatchString(str) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			if opts.ByteAligned && len(str)%2 != 0 {
+				return value, &ValidationError{Path: path, Type: string(StringMsgHexAlign), Msg: StringMsgMap[StringMsgHexAlign]}
+			}
+			if lower := strings.ToLower(str); opts.Lowercase && lower != str {
+				if r.Opts.convert() {
+					return lower, nil
+				}
+				return value, &ValidationError{Path: path, Type: string(StringMsgLower), Msg: StringMsgMap[StringMsgLower]}
+			}
+			return value, nil
+		},
+	}).self
+}
+
+// Base64 requires a base64 encoded string.
+func (s *StringSchema) Base64(opts Base64Options, msg ...string) *StringSchema {
+	return s.withRule(patternRule(StringMsgBase64, base64Regex(opts), msg...)).self
+}
+
+// DataURI requires a data URI ("data:image/png;base64,iVBORw0..."); base64
+// data is checked with opts.
+func (s *StringSchema) DataURI(opts Base64Options, msg ...string) *StringSchema {
+	data := base64Regex(opts)
+	return s.withRule(Rule{
+		Name: string(StringMsgDataURI),
+		Msg:  PickSchemaMsg(StringMsgMap[StringMsgDataURI], msg...),
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			str, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			m := dataURIRegex.FindStringSubmatch(str)
+			if m == nil {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			if m[1] != "" && !data.MatchString(m[2]) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			if _, err := url.PathUnescape(m[2]); m[1] == "" && err != nil {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			return value, nil
+		},
+	}).self
+}
+
+// Token requires letters, digits and underscores only (a-z, A-Z, 0-9, _).
+func (s *StringSchema) Token(msg ...string) *StringSchema {
+	return s.withRule(patternRule(StringMsgToken, tokenChars, msg...)).self
+}
+
+// Alphanum requires letters and digits only (a-z, A-Z, 0-9).
+func (s *StringSchema) Alphanum(msg ...string) *StringSchema {
+	return s.withRule(patternRule(StringMsgAlphanum, alphanumChars, msg...)).self
+}
+
+// Trim removes leading and trailing whitespace in convert mode; in strict
+// mode the value must already be trimmed. Trim(false) removes a Trim added
+// before, e.g. to a base schema; Trim(true) on a schema that already trims
+// keeps the rule where it is, only changing its message when one is given.
+func (s *StringSchema) Trim(enabled bool, msg ...string) *StringSchema {
+	c := s.Clone()
+	isTrim := func(r Rule) bool { return r.Name == string(StringMsgTrim) }
+	i := slices.IndexFunc(c.rules, isTrim)
+	switch {
+	case !enabled:
+		c.rules = slices.DeleteFunc(c.rules, isTrim)
+	case i < 0:
+		c.rules = append(c.rules, convertRule(StringMsgTrim, strings.TrimSpace, msg...))
+	case len(msg) > 0:
+		c.rules[i] = convertRule(StringMsgTrim, strings.TrimSpace, msg...)
+	}
+	return c
+}
+
+// Case converts the value to lowercase or uppercase in convert mode; in
+// strict mode the value must already be in that case.
+func (s *StringSchema) Case(c StringCase, msg ...string) *StringSchema {
+	switch c {
+	case CaseLower:
+		return s.withRule(convertRule(StringMsgLower, strings.ToLower, msg...)).self
+	case CaseUpper:
+		return s.withRule(convertRule(StringMsgUpper, strings.ToUpper, msg...)).self
+	}
+	panic(fmt.Sprintf("joi: unknown case %q", c))
+}
+
+// Lower is Case(CaseLower).
+func (s *StringSchema) Lower(msg ...string) *StringSchema {
+	return s.Case(CaseLower, msg...)
+}
+
+// Upper is Case(CaseUpper).
+func (s *StringSchema) Upper(msg ...string) *StringSchema {
+	return s.Case(CaseUpper, msg...)
+}
+
+// Replace replaces the matches of pattern, a *regexp.Regexp or a literal
+// string, with replacement in convert mode; in strict mode the value must not
+// contain any match. With a regexp, replacement may refer to submatches as in
+// regexp.Expand ("$1").
+func (s *StringSchema) Replace(pattern any, replacement string, msg ...string) *StringSchema {
+	var fn func(string) string
+	switch p := pattern.(type) {
+	case *regexp.Regexp:
+		fn = func(str string) string { return p.ReplaceAllString(str, replacement) }
+	case string:
+		fn = func(str string) string { return strings.ReplaceAll(str, p, replacement) }
+	default:
+		panic(fmt.Sprintf("joi: Replace pattern must be a *regexp.Regexp or a string, got %T", pattern))
+	}
+	r := convertRule(StringMsgReplace, fn, msg...)
+	r.Args = map[string]any{"pattern": fmt.Sprint(pattern)}
+	return s.withRule(r).self
+}
+
+// Normalize puts the value in the unicode normalization form given in convert
+// mode, so equivalent inputs compare and measure the same; in strict mode the
+// value must already be normalized.
+func (s *StringSchema) Normalize(form NormalizationForm, msg ...string) *StringSchema {
+	f, ok := normForms[form]
+	if !ok {
+		panic(fmt.Sprintf("joi: unknown normalization form %q", form))
+	}
+	r := convertRule(StringMsgNormalize, f.String, msg...)
+	r.Args = map[string]any{"form": string(form)}
+	return s.withRule(r).self
+}
+
+// convertRule builds a rule that applies fn in convert mode and, in strict
+// mode, fails when fn would change the value.
+func convertRule(name StringMsg, fn func(string) string, msg ...string) Rule {
+	return Rule{
+		Name: string(name),
+		Msg:  PickSchemaMsg(StringMsgMap[name], msg...),
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			str, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			converted := fn(str)
+			if converted == str {
+				return value, nil
+			}
+			if r.Opts.convert() {
+				return converted, nil
+			}
+			return value, &ValidationError{Path: path, Msg: r.Msg}
+		},
+	}
+}
+
+// atext are the characters allowed in the atoms of an email local part.
+var atext = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+/=?^_`{|}~-]+$")
+
+// checkEmail returns the type of the error found in email, if any.
+func checkEmail(email string, opts EmailOptions) StringMsg {
+	if strings.ContainsAny(email, "<>\"") {
+		if !opts.AllowDisplayName {
+			return StringMsgEmail
+		}
+		addr, err := mail.ParseAddress(email)
+		if err != nil {
+			return StringMsgEmail
+		}
+		email = addr.Address
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 || (!opts.IgnoreLength && len(email) > 254) {
+		return StringMsgEmail
+	}
+	local, domain := email[:at], email[at+1:]
+	unicodeOK := opts.AllowUnicode == nil || *opts.AllowUnicode
+
+	if local == "" || (!opts.IgnoreLength && len(local) > 64) {
+		return StringMsgEmailLocal
+	}
+	for _, atom := range strings.Split(local, ".") {
+		// non-ASCII letters are allowed by RFC 6531
+		ascii := strings.Map(func(r rune) rune {
+			if r >= utf8.RuneSelf && unicodeOK && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)) {
+				return 'a'
+			}
+			return r
+		}, atom)
+		if !atext.MatchString(ascii) {
+			return StringMsgEmailLocal
+		}
+	}
+
+	if strings.HasSuffix(domain, ".") {
+		return StringMsgEmailDomain
+	}
+	if !unicodeOK && strings.ContainsFunc(domain, func(r rune) bool { return r >= utf8.RuneSelf }) {
+		return StringMsgEmailDomain
+	}
+	if !validDomain(domain, DomainOptions{MinDomainSegments: opts.MinDomainSegments, AllowTLDs: opts.AllowTLDs, DenyTLDs: opts.DenyTLDs}) {
+		return StringMsgEmailDomain
+	}
+	return ""
+}
+
+// patternRule builds a rule requiring the value to match re.
+func patternRule(name StringMsg, re *regexp.Regexp, msg ...string) Rule {
+	return Rule{
+		Name: string(name),
+		Msg:  PickSchemaMsg(StringMsgMap[name], msg...),
+		Fn: func(r Rule, path string, value any) (any, *ValidationError) {
+			str, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			if !re.MatchString(str) {
+				return value, &ValidationError{Path: path, Msg: r.Msg}
+			}
+			return value, nil
+		},
+	}
+}
+
+var (
+	hexChars      = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	tokenChars    = regexp.MustCompile(`^\w+$`)
+	alphanumChars = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	dataURIRegex  = regexp.MustCompile(`^data:(?:[\w+.-]+/[\w+.-]+)?(?:;[\w.+-]+=[\w.+-]+)*(;base64)?,(.*)$`)
+)
+
+func guidRegex(versions []int) *regexp.Regexp {
+	version, variant := `[0-9a-fA-F]`, `[0-9a-fA-F]`
+	if len(versions) > 0 {
+		digits := make([]string, len(versions))
+		for i, v := range versions {
+			if v < 1 || v > 8 {
+				panic(fmt.Sprintf("joi: GUID version must be between 1 and 8, got %d", v))
+			}
+			digits[i] = strconv.Itoa(v)
+		}
+		version, variant = "["+strings.Join(digits, "")+"]", `[89abAB]`
+	}
+	var guids []string
+	for _, sep := range []string{"-", ":", ""} {
+		guids = append(guids, `[0-9a-fA-F]{8}`+sep+`[0-9a-fA-F]{4}`+sep+version+`[0-9a-fA-F]{3}`+sep+variant+`[0-9a-fA-F]{3}`+sep+`[0-9a-fA-F]{12}`)
+	}
+	guid := `(?:` + strings.Join(guids, "|") + `)`
+	return regexp.MustCompile(`^(?:` + guid + `|\{` + guid + `\}|\[` + guid + `\]|\(` + guid + `\))$`)
+}
+
+func base64Regex(opts Base64Options) *regexp.Regexp {
+	chars := `[A-Za-z0-9+/]`
+	if opts.URLSafe {
+		chars = `[A-Za-z0-9_-]`
+	}
+	tail := chars + `{2}==|` + chars + `{3}=`
+	if opts.PaddingRequired != nil && !*opts.PaddingRequired {
+		tail = chars + `{2}(?:==)?|` + chars + `{3}=?`
+	}
+	return regexp.MustCompile(`^(?:` + chars + `{4})*(?:` + tail + `)?$`)
+}
+
+// uriChars are the characters allowed in a URI by RFC 3986.
+var uriChars = regexp.MustCompile(`^[A-Za-z0-9\-._~:/?#\[\]@!$&'()*+,;=%]*$`)
+
+func validIP(str string, versions []IPVersion, cidr Presence) bool {
+	addr, prefixed := netip.Addr{}, strings.Contains(str, "/")
+	if prefixed {
+		prefix, err := netip.ParsePrefix(str)
+		if err != nil {
+			return false
+		}
+		addr = prefix.Addr()
+	} else {
+		a, err := netip.ParseAddr(str)
+		if err != nil {
+			return false
+		}
+		addr = a
+	}
+	if (cidr == PresenceRequired && !prefixed) || (cidr == PresenceForbidden && prefixed) {
+		return false
+	}
+	if len(versions) == 0 {
+		return true
+	}
+	version := IPv6
+	if addr.Is4() {
+		version = IPv4
+	}
+	return slices.Contains(versions, version)
+}
+
+func validHostname(str string) bool {
+	if _, err := netip.ParseAddr(str); err == nil {
+		return true
+	}
+	str = strings.TrimSuffix(str, ".")
+	if str == "" || len(str) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(str, ".") {
+		if !validLabel(label, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func validDomain(str string, opts DomainOptions) bool {
+	str = strings.TrimSuffix(str, ".")
+	if str == "" || len(str) > 253 {
+		return false
+	}
+	labels := strings.Split(str, ".")
+	if len(labels) < Coalesce(opts.MinDomainSegments, 2) {
+		return false
+	}
+	if opts.MaxDomainSegments > 0 && len(labels) > opts.MaxDomainSegments {
+		return false
+	}
+	for _, label := range labels {
+		if !validLabel(label, true) {
+			return false
+		}
+	}
+	tld := strings.ToLower(labels[len(labels)-1])
+	if !strings.HasPrefix(tld, "xn--") && strings.ContainsFunc(tld, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		return false
+	}
+	hasTLD := func(list []string) bool {
+		return slices.ContainsFunc(list, func(t string) bool { return strings.EqualFold(strings.TrimPrefix(t, "."), tld) })
+	}
+	if len(opts.AllowTLDs) > 0 && !hasTLD(opts.AllowTLDs) {
+		return false
+	}
+	return !hasTLD(opts.DenyTLDs)
+}
+
+// validLabel checks a label of a hostname (ASCII letters, digits and hyphens)
+// or, with unicode, of an internationalized domain name.
+func validLabel(label string, unicodeLetters bool) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		switch {
+		case r == '-', r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'):
+		case unicodeLetters && r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// truncateString cuts str down to limit units of encoding, without splitting
+// a rune (or a grapheme cluster with EncodingGraphemes).
+func truncateString(str string, limit int, encoding any) string {
+	switch encoding {
+	case EncodingBytes:
+		end := 0
+		for end < len(str) {
+			_, size := utf8.DecodeRuneInString(str[end:])
+			if end+size > limit {
+				break
+			}
+			end += size
+		}
+		return str[:end]
+	case EncodingGraphemes:
+		end, state := 0, -1
+		for n := 0; n < limit && end < len(str); n++ {
+			var cluster string
+			cluster, _, _, state = uniseg.FirstGraphemeClusterInString(str[end:], state)
+			end += len(cluster)
+		}
+		return str[:end]
+	}
+	end := 0
+	for n := 0; n < limit && end < len(str); n++ {
+		_, size := utf8.DecodeRuneInString(str[end:])
+		end += size
+	}
+	return str[:end]
+}
+
+func stringLength(str string, encoding any) int {
+	switch encoding {
+	case EncodingBytes:
+		return len(str)
+	case EncodingGraphemes:
+		return uniseg.GraphemeClusterCount(str)
+	}
+	return utf8.RuneCountInString(str)
 }
 
 // --- constructor ---
@@ -0,0 +1,652 @@
+package joi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// structField is an exported field of a struct, keyed by its json name.
+// Fields of embedded structs are promoted, as encoding/json does.
+type structField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	tag       string
+	tagged    bool // the name comes from the json tag
+	omitempty bool
+	omitzero  bool
+}
+
+// omitted reports whether encoding/json would leave the field value v out;
+// such fields are validated as missing.
+func (f structField) omitted(v reflect.Value) bool {
+	if f.omitzero && v.IsZero() {
+		return true
+	}
+	if !f.omitempty {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
+
+var structFieldsCache sync.Map // reflect.Type → []structField
+
+// structFields returns the fields of t encoding/json would encode, in index
+// order. As in encoding/json, a promoted field is hidden by a shallower field
+// of the same name; at the same depth a tagged field wins and, without one,
+// the conflicting fields are all left out.
+func structFields(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+	all := collectFields(t, map[reflect.Type]bool{t: true})
+	byName := map[string][]structField{}
+	for _, f := range all {
+		byName[f.name] = append(byName[f.name], f)
+	}
+	var fields []structField
+	for _, f := range all {
+		if dominant, ok := dominantField(byName[f.name]); ok && slices.Equal(dominant.index, f.index) {
+			fields = append(fields, f)
+		}
+	}
+	cached, _ := structFieldsCache.LoadOrStore(t, fields)
+	return cached.([]structField)
+}
+
+// collectFields returns every field of t, with the fields of embedded structs
+// in place of them; visited holds the embedded types on the way, so a type
+// embedding itself ends the recursion.
+func collectFields(t reflect.Type, visited map[reflect.Type]bool) []structField {
+	var fields []structField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, jsonOpts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if visited[ft] {
+					continue
+				}
+				visited[ft] = true
+				for _, sf := range collectFields(ft, visited) {
+					sf.index = append([]int{i}, sf.index...)
+					fields = append(fields, sf)
+				}
+				delete(visited, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		fields = append(fields, structField{
+			name:      Coalesce(name, f.Name),
+			index:     f.Index,
+			typ:       f.Type,
+			tag:       f.Tag.Get("joi"),
+			tagged:    name != "",
+			omitempty: slices.Contains(strings.Split(jsonOpts, ","), "omitempty"),
+			omitzero:  slices.Contains(strings.Split(jsonOpts, ","), "omitzero"),
+		})
+	}
+	return fields
+}
+
+// dominantField returns the field, among fields sharing a name, that
+// encoding/json would use, if any.
+func dominantField(fields []structField) (structField, bool) {
+	depth := len(fields[0].index)
+	for _, f := range fields[1:] {
+		depth = min(depth, len(f.index))
+	}
+	var shallowest, tagged []structField
+	for _, f := range fields {
+		if len(f.index) == depth {
+			shallowest = append(shallowest, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+// plainValue dereferences pointers and turns named string and bool types into
+// string and bool, so the value is understood by the basic schemas.
+func plainValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return v.Interface()
+}
+
+// toMap returns value as a map[string]any when it is one, a struct (or a
+// pointer to one) or a map with string keys.
+func toMap(value any) (map[string]any, bool) {
+	if m, ok := value.(map[string]any); ok {
+		return m, true
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		m := map[string]any{}
+		for _, f := range structFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil || f.omitted(fv) {
+				continue // nil embedded pointer or empty value
+			}
+			m[f.name] = plainValue(fv)
+		}
+		return m, true
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		m := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			m[it.Key().String()] = plainValue(it.Value())
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// toSlice returns value as a []any when it is a slice or an array.
+func toSlice(value any) ([]any, bool) {
+	if s, ok := value.([]any); ok {
+		return s, true
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	s := make([]any, v.Len())
+	for i := range v.Len() {
+		s[i] = plainValue(v.Index(i))
+	}
+	return s, true
+}
+
+// --- struct tags ---
+
+var structSchemas sync.Map // reflect.Type → *ObjectSchema
+
+// StructSchema builds an object schema from the exported fields of v, a struct
+// or a pointer to one, keyed by their json names. Each field is described by
+// its joi tag, a comma-separated list starting with an optional type followed
+// by rules:
+//
+//	Name  string   `json:"name" joi:"string,min=3,max=20,required"`
+//	Age   int      `joi:"integer,min=18"`
+//	Role  string   `joi:"valid=admin|user,default=user"`
+//	Email string   `joi:"email"`
+//	Site  string   `joi:"uri"`
+//	Tags  []string `joi:"max=5"`
+//
+// A value holding commas is quoted with single quotes, e.g.
+// `joi:"regex='^[A-Z]{2,3}$'"`. The type (string, number, integer, boolean,
+// date, object, array or any) is inferred from the field when omitted. Nested structs, slices and maps of
+// structs use the tags of their own fields, at every level of recursive
+// types. A field tagged "-" is not validated. Schemas are built once per type.
+//
+// As with encoding/json, a field is missing only when it holds nil or is
+// tagged omitempty (or omitzero) and holds an empty value, so required and
+// default work best on pointers and omitempty fields.
+func StructSchema(v any) (*ObjectSchema, error) {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	t, ok = structType(t)
+	if !ok {
+		return nil, fmt.Errorf("joi: StructSchema needs a struct, got %v", t)
+	}
+	return structSchema(t, map[reflect.Type]bool{})
+}
+
+// structType returns t, a struct type or a pointer to one, without pointers.
+func structType(t reflect.Type) (reflect.Type, bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t != nil && t.Kind() == reflect.Struct && t != timeType
+}
+
+// ValidateStruct validates v, a struct or a pointer to one, against the schema
+// built from its joi tags (see StructSchema). Any other v is reported as an
+// object_base error. It panics if the tags are invalid.
+func ValidateStruct(v any) ValidationErrors {
+	return ValidateStructWithOpts(v, ValidateOptions{})
+}
+
+func ValidateStructWithOpts(v any, opts ValidateOptions) ValidationErrors {
+	if _, ok := structType(reflect.TypeOf(v)); !ok {
+		opts = opts.begin()
+		ctx := map[string]any{"label": Coalesce(opts.path(), "value"), "path": opts.path(), "value": v}
+		return ValidationErrors{newError(string(ObjectMsgBase), ObjectMsgMap[ObjectMsgBase], ctx, v, opts)}
+	}
+	schema, err := StructSchema(v)
+	if err != nil {
+		panic(err)
+	}
+	_, errs := schema.ValidateWithOpts(v, opts)
+	return errs
+}
+
+// structSchema returns the schema of the struct type t. building holds the
+// types whose schema is being built, which recursive fields refer to through
+// a lazyStructSchema; only the schema of the outermost type is cached, so a
+// failed build never leaves a partial schema behind.
+func structSchema(t reflect.Type, building map[reflect.Type]bool) (*ObjectSchema, error) {
+	if cached, ok := structSchemas.Load(t); ok {
+		return cached.(*ObjectSchema), nil
+	}
+	building[t] = true
+	defer delete(building, t)
+
+	s := Object(nil)
+	for _, f := range structFields(t) {
+		schema, err := tagSchema(f.typ, f.tag, building)
+		if err != nil {
+			return nil, fmt.Errorf("joi: field %s.%s: %w", t, f.name, err)
+		}
+		s = s.Keys(Key(f.name, schema))
+	}
+	if len(building) > 1 {
+		return s, nil
+	}
+	cached, _ := structSchemas.LoadOrStore(t, s)
+	return cached.(*ObjectSchema), nil
+}
+
+// lazyStructSchema is the schema of a field whose struct type is still being
+// built, i.e. a recursive type. The schema of the type is looked up when the
+// field is validated.
+type lazyStructSchema struct {
+	*AnySchema[*lazyStructSchema]
+	t reflect.Type
+}
+
+func newLazyStructSchema(t reflect.Type) *lazyStructSchema {
+	s := &lazyStructSchema{t: t}
+	s.AnySchema = &AnySchema[*lazyStructSchema]{self: s, label: "value"}
+	return s
+}
+
+func (s *lazyStructSchema) rebase(base *AnySchema[*lazyStructSchema]) *lazyStructSchema {
+	c := *s
+	c.AnySchema = base
+	return &c
+}
+
+func (s *lazyStructSchema) validateInner(value any, opts ValidateOptions) (any, ValidationErrors) {
+	schema, err := structSchema(s.t, map[reflect.Type]bool{})
+	if err != nil {
+		panic(err) // the tags were already checked when building the outer type
+	}
+	return schema.ValidateWithOpts(value, opts)
+}
+
+// tagSchema builds the schema of a field of type t from its joi tag.
+func tagSchema(t reflect.Type, tag string, building map[reflect.Type]bool) (Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if tag == "-" {
+		return Any[Schema](), nil
+	}
+	opts, err := splitTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	kind := ""
+	if len(opts) > 0 && isTagType(opts[0]) {
+		kind, opts = opts[0], opts[1:]
+	}
+	schema, err := typeSchema(t, kind, building)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		if opt == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(opt, "=")
+		if len(arg) >= 2 && arg[0] == '\'' && arg[len(arg)-1] == '\'' {
+			arg = arg[1 : len(arg)-1]
+		}
+		if schema, err = applyTag(schema, t, strings.TrimSpace(name), arg); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// splitTag splits a joi tag on the commas outside single quotes, so a value
+// holding commas can be quoted: regex='^[A-Z]{2,3}$'.
+func splitTag(tag string) ([]string, error) {
+	var (
+		opts   []string
+		start  int
+		quoted bool
+	)
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				opts = append(opts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", tag)
+	}
+	return append(opts, tag[start:]), nil
+}
+
+func isTagType(name string) bool {
+	switch name {
+	case "string", "number", "integer", "boolean", "date", "object", "array", "any":
+		return true
+	}
+	return false
+}
+
+// typeSchema returns the schema for kind, or the one inferred from t when
+// kind is empty.
+func typeSchema(t reflect.Type, kind string, building map[reflect.Type]bool) (Schema, error) {
+	if kind == "" {
+		switch {
+		case t == timeType:
+			kind = "date"
+		case t.Kind() == reflect.String:
+			kind = "string"
+		case t.Kind() == reflect.Bool:
+			kind = "boolean"
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+			kind = "number"
+		case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+			kind = "object"
+		case t.Kind() == reflect.Slice, t.Kind() == reflect.Array:
+			kind = "array"
+		default:
+			kind = "any"
+		}
+	}
+	switch kind {
+	case "string":
+		return String(), nil
+	case "number":
+		return Number(), nil
+	case "integer":
+		return Number().Integer(), nil
+	case "boolean":
+		return Boolean(), nil
+	case "date":
+		return Date(), nil
+	case "object":
+		switch {
+		case t.Kind() == reflect.Struct && t != timeType && building[t]:
+			return newLazyStructSchema(t), nil
+		case t.Kind() == reflect.Struct && t != timeType:
+			return structSchema(t, building)
+		case t.Kind() == reflect.Map:
+			values, err := tagSchema(t.Elem(), "", building)
+			if err != nil {
+				return nil, err
+			}
+			return Object(nil).Pattern(Any[Schema](), values), nil
+		}
+		return Object(nil).Unknown(true), nil
+	case "array":
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return Array(), nil
+		}
+		items, err := tagSchema(t.Elem(), "", building)
+		if err != nil {
+			return nil, err
+		}
+		return Array().Items(items), nil
+	}
+	return Any[Schema](), nil
+}
+
+// applyTag applies the tag option name=arg to schema.
+func applyTag(schema Schema, t reflect.Type, name, arg string) (Schema, error) {
+	var (
+		s   Schema
+		err error
+	)
+	switch sc := schema.(type) {
+	case *StringSchema:
+		s, err = stringTag(sc, name, arg)
+	case *NumberSchema:
+		s, err = numberTag(sc, name, arg)
+	case *ArraySchema:
+		s, err = limitTag(name, arg, sc.Min, sc.Max, sc.Length)
+	case *ObjectSchema:
+		s, err = limitTag(name, arg, sc.Min, sc.Max, sc.Length)
+	}
+	if s != nil || err != nil {
+		return s, err
+	}
+	switch sc := schema.(type) {
+	case *StringSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *NumberSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *BooleanSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *DateSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *ArraySchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *ObjectSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *lazyStructSchema:
+		return anyTag(sc.AnySchema, t, name, arg)
+	case *AnySchema[Schema]:
+		return anyTag(sc, t, name, arg)
+	}
+	return nil, fmt.Errorf("unsupported tag option %q", name)
+}
+
+func stringTag(s *StringSchema, name, arg string) (Schema, error) {
+	switch name {
+	case "min", "max", "length", "len":
+		return limitTag(name, arg, s.Min, s.Max, s.Length)
+	case "regex", "pattern":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, err
+		}
+		return s.Regex(re), nil
+	case "email":
+		return s.Email(), nil
+	case "ip":
+		return s.IP(IPOptions{}), nil
+	case "hostname":
+		return s.Hostname(), nil
+	case "domain":
+		return s.Domain(DomainOptions{}), nil
+	case "uri":
+		return s.URI(URIOptions{}), nil
+	case "guid", "uuid":
+		return s.UUID(), nil
+	case "hex":
+		return s.Hex(HexOptions{}), nil
+	case "base64":
+		return s.Base64(Base64Options{}), nil
+	case "datauri":
+		return s.DataURI(Base64Options{}), nil
+	case "token":
+		return s.Token(), nil
+	case "alphanum":
+		return s.Alphanum(), nil
+	case "encoding":
+		switch enc := StringEncoding(arg); enc {
+		case EncodingRunes, EncodingGraphemes, EncodingBytes:
+			return s.Encoding(enc), nil
+		}
+		return nil, fmt.Errorf("tag option encoding: unknown encoding %q", arg)
+	case "normalize":
+		if _, ok := normForms[NormalizationForm(arg)]; !ok {
+			return nil, fmt.Errorf("tag option normalize: unknown form %q", arg)
+		}
+		return s.Normalize(NormalizationForm(arg)), nil
+	case "trim":
+		return s.Trim(true), nil
+	case "lowercase", "lower":
+		return s.Lower(), nil
+	case "uppercase", "upper":
+		return s.Upper(), nil
+	}
+	return nil, nil
+}
+
+func numberTag(s *NumberSchema, name, arg string) (Schema, error) {
+	switch name {
+	case "min", "max":
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("tag option %s: %w", name, err)
+		}
+		if math.IsNaN(n) {
+			return nil, fmt.Errorf("tag option %s: NaN is not a limit", name)
+		}
+		if name == "min" {
+			return s.Min(n), nil
+		}
+		return s.Max(n), nil
+	case "integer":
+		return s.Integer(), nil
+	case "positive":
+		return s.Positive(), nil
+	case "negative":
+		return s.Negative(), nil
+	}
+	return nil, nil
+}
+
+func limitTag[T Schema](name, arg string, min, max, length func(limit any, msg ...string) T) (Schema, error) {
+	var rule func(limit any, msg ...string) T
+	switch name {
+	case "min":
+		rule = min
+	case "max":
+		rule = max
+	case "length", "len":
+		rule = length
+	default:
+		return nil, nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("tag option %s: %w", name, err)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("tag option %s: %d is negative", name, n)
+	}
+	return rule(n), nil
+}
+
+func anyTag[T Schema](s *AnySchema[T], t reflect.Type, name, arg string) (Schema, error) {
+	switch name {
+	case "required":
+		return s.Required().self, nil
+	case "optional":
+		return s.Optional().self, nil
+	case "forbidden":
+		return s.Forbidden().self, nil
+	case "strip":
+		return s.Strip().self, nil
+	case "label":
+		return s.Label(arg).self, nil
+	case "valid", "invalid":
+		var list []any
+		for _, item := range strings.Split(arg, "|") {
+			v, err := tagValue(t, item)
+			if err != nil {
+				return nil, fmt.Errorf("tag option %s: %w", name, err)
+			}
+			list = append(list, v)
+		}
+		if name == "valid" {
+			return s.Valid(list).self, nil
+		}
+		return s.Invalid(list).self, nil
+	case "default":
+		v, err := tagValue(t, arg)
+		if err != nil {
+			return nil, fmt.Errorf("tag option %s: %w", name, err)
+		}
+		return s.Default(v).self, nil
+	}
+	return nil, fmt.Errorf("unsupported tag option %q", name)
+}
+
+// tagValue parses a value written in a tag as the field type t would hold it
+// once read from the struct (see plainValue).
+func tagValue(t reflect.Type, s string) (any, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return s, nil
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		return reflect.ValueOf(n).Convert(t).Interface(), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		return reflect.ValueOf(n).Convert(t).Interface(), err
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, t.Bits())
+		return reflect.ValueOf(n).Convert(t).Interface(), err
+	}
+	return s, nil
+}
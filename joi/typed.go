@@ -0,0 +1,94 @@
+package joi
+
+import (
+	"reflect"
+	"time"
+)
+
+// TypedSchema wraps a schema so its result is decoded (see ValidateInto) into
+// a T, letting the compiler check how it is used.
+type TypedSchema[T any] struct {
+	schema Schema
+}
+
+// Builder is a schema of type S, or the AnySchema embedded in one as returned
+// by methods like Required or Default, e.g. String().Min(3).Required() is a
+// Builder[*StringSchema].
+type Builder[S any] interface {
+	Schema
+	outer() S
+}
+
+// Numeric is the set of types a TNumber schema can produce.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func (s TypedSchema[T]) Validate(value any) (T, ValidationErrors) {
+	return s.ValidateWithOpts(value, ValidateOptions{})
+}
+
+func (s TypedSchema[T]) ValidateWithOpts(value any, opts ValidateOptions) (T, ValidationErrors) {
+	return validateAs[T](s.schema, value, opts)
+}
+
+// Schema returns the wrapped schema, e.g. to use it as an object key.
+func (s TypedSchema[T]) Schema() Schema {
+	return s.schema
+}
+
+// Typed wraps schema so its result is decoded into a T.
+func Typed[T any](schema Schema) TypedSchema[T] {
+	return TypedSchema[T]{schema: schema}
+}
+
+// TString returns a typed String schema, using schema for its rules when given:
+//
+//	name := joi.TString(joi.String().Min(3))
+//	v, errs := name.Validate(input) // v is a string
+func TString(schema ...Builder[*StringSchema]) TypedSchema[string] {
+	return Typed[string](firstOr(schema, String))
+}
+
+// TNumber returns a typed Number schema; values that don't fit N (e.g. 1.5
+// for an int) are reported as any_decode errors.
+func TNumber[N Numeric](schema ...Builder[*NumberSchema]) TypedSchema[N] {
+	return Typed[N](firstOr(schema, Number))
+}
+
+func TBoolean(schema ...Builder[*BooleanSchema]) TypedSchema[bool] {
+	return Typed[bool](firstOr(schema, Boolean))
+}
+
+func TDate(schema ...Builder[*DateSchema]) TypedSchema[time.Time] {
+	return Typed[time.Time](firstOr(schema, Date))
+}
+
+// TArray returns a typed Array schema whose items are decoded into E.
+func TArray[E any](schema ...Builder[*ArraySchema]) TypedSchema[[]E] {
+	return Typed[[]E](firstOr(schema, Array))
+}
+
+// TObject returns a typed Object schema decoded into T, usually a struct.
+// Without schema, the one built from the joi tags of T is used (see
+// StructSchema); it panics if those tags are invalid.
+func TObject[T any](schema ...Builder[*ObjectSchema]) TypedSchema[T] {
+	if len(schema) > 0 {
+		return Typed[T](schema[0])
+	}
+	var zero T
+	s, err := StructSchema(reflect.TypeOf(&zero).Elem())
+	if err != nil {
+		panic(err)
+	}
+	return Typed[T](s)
+}
+
+func firstOr[S Schema](schemas []Builder[S], build func(msg ...string) S) Schema {
+	if len(schemas) > 0 {
+		return schemas[0]
+	}
+	return build()
+}
@@ -1,25 +1,56 @@
 package joi
 
 import (
+	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
+	"math/big"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
 
-func RunValidation(rules []Rule, label, path string, value any) (any, []ValidationError) {
-	var errs []ValidationError
+func RunValidation(rules []Rule, label, path string, value any) (any, ValidationErrors) {
+	return RunValidationWithOpts(rules, label, value, ValidateOptions{Path: &path})
+}
+
+func RunValidationWithOpts(rules []Rule, label string, value any, opts ValidateOptions) (any, ValidationErrors) {
+	opts = opts.begin()
+	path := opts.path()
+	var errs ValidationErrors
 	current := value
 
 	for _, r := range rules {
+		if opts.halted() {
+			break
+		}
+		r.Opts = opts
+		args := r.Args
+		r.Args = resolveArgs(args, current, opts)
 		newVal, err := r.Fn(r, path, current)
 		if err != nil {
 			msg := Coalesce(r.Msg, err.Msg)
+			if err.Type != "" && err.Type != r.Name {
+				// the rule reported an error of another type, with its own message
+				msg = err.Msg
+			}
 			ctx := map[string]any{"label": label, "path": path, "value": current}
 			maps.Copy(ctx, r.Args)
+			maps.Copy(ctx, err.Context)
+			if ref, ok := args[fmt.Sprint(ctx["arg"])].(Reference); ok {
+				ctx["ref"] = ref
+			}
 			err.Msg = RenderTemplate(msg, ctx)
+			err.Type = Coalesce(err.Type, r.Name)
+			err.Context = ctx
+			err.Value = current
+			if err.Segments == nil {
+				err.Segments = opts.segments
+			}
 			errs = append(errs, *err)
+			opts.report(1)
 		}
 		if newVal != nil {
 			current = newVal
@@ -71,6 +102,9 @@ func ValueInList(value any, list []any) bool {
 	return false
 }
 
+// DATE_LAYOUTS are the layouts, besides PARSE_LAYOUT, tried by ParseDate.
+var DATE_LAYOUTS = []string{time.RFC3339Nano, time.DateOnly}
+
 func ParseDate(value any) (time.Time, bool) {
 	switch v := value.(type) {
 	case time.Time:
@@ -80,6 +114,11 @@ func ParseDate(value any) (time.Time, bool) {
 		if t, err := time.Parse(PARSE_LAYOUT, v); err == nil {
 			return t, true
 		}
+		for _, layout := range DATE_LAYOUTS {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
 		return time.Time{}, false
 	case int64:
 		return time.Unix(v, 0), true
@@ -91,6 +130,156 @@ func ParseDate(value any) (time.Time, bool) {
 	}
 }
 
+// Reach returns the value found by walking segments (map keys and slice
+// indexes) from value.
+func Reach(value any, segments []any) (any, bool) {
+	current := value
+	for _, segment := range segments {
+		switch c := current.(type) {
+		case map[string]any:
+			v, ok := c[fmt.Sprint(segment)]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		case []any:
+			i, ok := segment.(int)
+			if !ok || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// ParsePath splits a path like "users[0].name" into its segments, map keys as
+// strings and slice indexes as ints.
+func ParsePath(path string) []any {
+	segments := []any{}
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key != "" {
+			segments = append(segments, key)
+		}
+		for rest != "" {
+			var index string
+			index, rest, _ = strings.Cut(rest, "]")
+			if i, err := strconv.Atoi(index); err == nil {
+				segments = append(segments, i)
+			} else {
+				segments = append(segments, index)
+			}
+			_, rest, _ = strings.Cut(rest, "[")
+		}
+	}
+	return segments
+}
+
+// NormalizeNumber converts any Go numeric kind (including named types) and
+// json.Number to int64, uint64 or float64. NaN is not a number.
+func NormalizeNumber(value any) (any, bool) {
+	if n, ok := value.(json.Number); ok {
+		if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+			return i, true
+		}
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			return u, true
+		}
+		if f, err := strconv.ParseFloat(string(n), 64); err == nil && !math.IsNaN(f) {
+			return f, true
+		}
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return nil, false
+		}
+		return rv.Float(), true
+	default:
+		return nil, false
+	}
+}
+
+// CompareNumbers compares two numbers of any numeric kind without losing
+// precision on large int64/uint64 values. It returns -1, 0 or +1, and false
+// when either value is not a number.
+func CompareNumbers(a, b any) (int, bool) {
+	x, ok := bigNumber(a)
+	if !ok {
+		return 0, false
+	}
+	y, ok := bigNumber(b)
+	if !ok {
+		return 0, false
+	}
+	return x.Cmp(y), true
+}
+
+func bigNumber(value any) (*big.Float, bool) {
+	n, ok := NormalizeNumber(value)
+	if !ok {
+		return nil, false
+	}
+	switch v := n.(type) {
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	case uint64:
+		return new(big.Float).SetUint64(v), true
+	default:
+		return new(big.Float).SetFloat64(v.(float64)), true
+	}
+}
+
 func Ptr[T any](v T) *T {
 	return &v
 }
+
+// deepClone copies the maps and slices in v, recursively, so a value shared by
+// a schema (e.g. a default) is never handed out twice.
+func deepClone(v any) any {
+	if v == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(v)).Interface()
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for it := v.MapRange(); it.Next(); {
+			c.SetMapIndex(it.Key(), cloneValue(it.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneValue(v.Elem()))
+		return c
+	}
+	return v
+}
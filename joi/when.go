@@ -0,0 +1,73 @@
+package joi
+
+// WhenOpts describes the schemas applied by AnySchema.When.
+type WhenOpts struct {
+	// Is is the condition; when nil, the condition is met by any truthy value
+	// (not nil, false, 0 or "").
+	Is        Schema
+	Then      Schema
+	Otherwise Schema
+	// Switch lists conditions tried in order; the first match wins and
+	// Otherwise applies when none matches. Is and Then are ignored.
+	Switch []WhenCase
+}
+
+type WhenCase struct {
+	Is   Schema
+	Then Schema
+}
+
+type when struct {
+	ref  Reference // value the condition is checked against
+	is   Schema    // condition checked against the value itself
+	opts WhenOpts
+}
+
+// branch returns the schema to apply to value, or nil.
+func (w when) branch(value any, opts ValidateOptions) Schema {
+	if w.is != nil {
+		if w.matches(w.is, value, opts) {
+			return w.opts.Then
+		}
+		return w.opts.Otherwise
+	}
+
+	subject, _ := w.ref.resolve(value, opts)
+
+	cases := w.opts.Switch
+	if len(cases) == 0 {
+		cases = []WhenCase{{Is: w.opts.Is, Then: w.opts.Then}}
+	}
+	for _, c := range cases {
+		if w.matches(c.Is, subject, opts) {
+			return c.Then
+		}
+	}
+	return w.opts.Otherwise
+}
+
+func (w when) matches(is Schema, subject any, opts ValidateOptions) bool {
+	if subject == nil {
+		return false
+	}
+	if is == nil {
+		return truthy(subject)
+	}
+	_, errs := is.ValidateWithOpts(subject, opts.trial())
+	return len(errs) == 0
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if cmp, ok := CompareNumbers(value, 0); ok {
+		return cmp != 0
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package joi_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func TestAlternativesSchema_Any(t *testing.T) {
+	schema := joi.Alternatives(joi.String().Regex(uuidRe), joi.Number().Integer().Positive())
+
+	val, errs := schema.Validate("123e4567-e89b-12d3-a456-426614174000")
+	assert.Empty(t, errs)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", val)
+
+	val, errs = schema.Validate(float64(42))
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(42), val)
+}
+
+func TestAlternativesSchema_NoMatch(t *testing.T) {
+	schema := joi.Alternatives(joi.String().Regex(uuidRe), joi.Number().Integer().Positive())
+
+	_, errs := schema.ValidateWithOpts("nope", joi.ValidateOptions{Path: joi.Ptr("id")})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AlternativesMsgMatch), errs[0].Type)
+	assert.Equal(t, "id", errs[0].Path)
+	assert.Contains(t, errs[0].Msg, "fails to match the required pattern")
+
+	details, ok := errs[0].Context["details"].(joi.ValidationErrors)
+	assert.True(t, ok)
+	assert.True(t, details.Has(string(joi.StringMsgRegex)))
+	assert.True(t, details.Has(string(joi.NumberMsgBase)))
+
+	_, errs = schema.Validate(true)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AlternativesMsgAny), errs[0].Type)
+}
+
+func TestAlternativesSchema_MatchOne(t *testing.T) {
+	schema := joi.Alternatives(joi.Number().Min(0), joi.Number().Max(10)).Match(joi.MatchOne)
+
+	_, errs := schema.Validate(20)
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(5)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AlternativesMsgOne), errs[0].Type)
+}
+
+func TestAlternativesSchema_MatchAll(t *testing.T) {
+	schema := joi.Alternatives().Try(joi.Number().Min(0), joi.Number().Max(10)).Match(joi.MatchAll)
+
+	_, errs := schema.Validate(5)
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(20)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AlternativesMsgAll), errs[0].Type)
+}
+
+func TestAlternativesSchema_MatchUnknownMode(t *testing.T) {
+	assert.Panics(t, func() { joi.Alternatives().Match("bogus") })
+	assert.NotPanics(t, func() { joi.Alternatives().Match("any") })
+}
+
+func TestAlternativesSchema_Composes(t *testing.T) {
+	id := joi.Alternatives(joi.String().Regex(uuidRe), joi.Number().Integer().Positive())
+	schema := joi.Object(map[string]joi.Schema{
+		"id":   id.Required(),
+		"refs": joi.Array().Items(id),
+	})
+
+	_, errs := schema.Validate(map[string]any{"id": 1, "refs": []any{2, "x"}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "refs[1]", errs[0].Path)
+
+	_, errs = schema.Validate(map[string]any{})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+}
+
+func TestAlternativesSchema_AbortEarlyDoesNotStopBranches(t *testing.T) {
+	schema := joi.Alternatives(joi.String(), joi.Number())
+
+	val, errs := schema.ValidateWithOpts(7, joi.ValidateOptions{AbortEarly: true})
+	assert.Empty(t, errs)
+	assert.Equal(t, 7, val)
+}
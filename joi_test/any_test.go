@@ -1,6 +1,7 @@
 package joi_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/leandroluk/go-joi/joi"
@@ -98,3 +99,91 @@ func TestAnySchema_ValidNilValue(t *testing.T) {
 	_, errs := schema.ValidateWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("field")})
 	assert.Empty(t, errs) // covers nil branch
 }
+
+func TestAnySchema_BuilderDoesNotAlias(t *testing.T) {
+	base := joi.Any[joi.Schema]().Label("base")
+	required := base.Required()
+
+	_, errs := base.Validate(nil)
+	assert.Empty(t, errs)
+
+	_, errs = required.Validate(nil)
+	assert.NotEmpty(t, errs)
+}
+
+func TestAnySchema_RequiredKeepsOuterSchema(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"a": joi.String()}).Required()
+
+	_, errs := schema.Validate(map[string]any{"a": 1})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "a", errs[0].Path)
+}
+
+func TestAnySchema_Forbidden(t *testing.T) {
+	schema := joi.Any[joi.Schema]().Forbidden()
+
+	_, errs := schema.Validate(nil)
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(1)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgUnknown), errs[0].Type)
+	assert.Equal(t, "value is not allowed", errs[0].Msg)
+
+	_, errs = joi.Any[joi.Schema]().Forbidden("no way").Validate(1)
+	assert.Equal(t, "no way", errs[0].Msg)
+}
+
+func TestAnySchema_PresenceOption(t *testing.T) {
+	opts := joi.ValidateOptions{Presence: joi.PresenceRequired}
+
+	_, errs := joi.String().ValidateWithOpts(nil, opts)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+
+	_, errs = joi.String().Optional().ValidateWithOpts(nil, opts)
+	assert.Empty(t, errs)
+
+	_, errs = joi.String().Required("custom").Optional().Validate(nil)
+	assert.Empty(t, errs, "the last presence set wins")
+
+	_, errs = joi.String().ValidateWithOpts("x", joi.ValidateOptions{Presence: joi.PresenceForbidden})
+	assert.Equal(t, string(joi.AnyMsgUnknown), errs[0].Type)
+}
+
+func TestAnySchema_DefaultIsCloned(t *testing.T) {
+	schema := joi.Any[joi.Schema]().Default(map[string]any{"tags": []any{"a"}})
+
+	first, _ := schema.Validate(nil)
+	first.(map[string]any)["tags"].([]any)[0] = "changed"
+	first.(map[string]any)["extra"] = true
+
+	second, _ := schema.Validate(nil)
+	assert.Equal(t, map[string]any{"tags": []any{"a"}}, second)
+}
+
+func TestAnySchema_DefaultFunc(t *testing.T) {
+	calls := 0
+	schema := joi.Number().DefaultFunc(func(parent map[string]any, h joi.DefaultHelpers) (any, error) {
+		calls++
+		assert.Nil(t, parent)
+		return 42, nil
+	})
+
+	val, errs := schema.Validate(nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, 42, val)
+
+	val, _ = schema.Validate(7)
+	assert.Equal(t, 7, val)
+	assert.Equal(t, 1, calls, "not called when the value is present")
+
+	failing := joi.Number().DefaultFunc(func(map[string]any, joi.DefaultHelpers) (any, error) {
+		return nil, errors.New("boom")
+	})
+	_, errs = failing.ValidateWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("id")})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgDefault), errs[0].Type)
+	assert.Equal(t, "id", errs[0].Path)
+	assert.Equal(t, "boom", errs[0].Context["message"])
+}
@@ -112,8 +112,7 @@ func TestArraySchema_DefaultNonArrayTriggersBaseError(t *testing.T) {
 }
 
 func TestArraySchema_DefaultBranchIsHit(t *testing.T) {
-	as := joi.Array()
-	as.AnySchema.Default([]any{"__d__"})
+	as := joi.Array().AnySchema.Default([]any{"__d__"})
 
 	got, errs := as.ValidateWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("field")})
 
@@ -130,3 +129,63 @@ func TestArraySchema_BaseAllowsNil(t *testing.T) {
 	assert.Empty(t, errs)
 	assert.Nil(t, val)
 }
+
+func TestArraySchema_DefaultKeepsItems(t *testing.T) {
+	schema := joi.Array().Items(joi.String()).Default([]any{1})
+
+	_, errs := schema.ValidateWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("field")})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "field[0]", errs[0].Path)
+}
+
+func TestArraySchema_ItemsDoesNotAlias(t *testing.T) {
+	base := joi.Array()
+	strs := base.Items(joi.String())
+
+	_, errs1 := base.Validate([]any{1})
+	assert.Empty(t, errs1)
+
+	_, errs2 := strs.Validate([]any{1})
+	assert.NotEmpty(t, errs2)
+}
+
+func TestArraySchema_MaxErrors(t *testing.T) {
+	schema := joi.Array().Items(joi.String().Min(3))
+
+	items := make([]any, 10000)
+	for i := range items {
+		items[i] = 1
+	}
+
+	_, errs := schema.ValidateWithOpts(items, joi.ValidateOptions{MaxErrors: 5})
+	assert.Len(t, errs, 5)
+	assert.Equal(t, "[4]", errs[4].Path)
+}
+
+func TestArraySchema_AbortEarly(t *testing.T) {
+	schema := joi.Array().Min(3).Items(joi.String())
+
+	_, errs := schema.ValidateWithOpts([]any{1, 2}, joi.ValidateOptions{AbortEarly: true})
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Msg, "at least 3 items")
+}
+
+func TestArraySchema_ItemsStrip(t *testing.T) {
+	schema := joi.Array().Items(joi.Number().Strip())
+
+	parsed, errs := schema.Validate([]any{1, 2})
+	assert.Empty(t, errs)
+	assert.Equal(t, []any{}, parsed)
+}
+
+func TestArraySchema_StripUnknownArrays(t *testing.T) {
+	schema := joi.Array().Items(joi.Object(map[string]joi.Schema{"id": joi.Number().Required()}))
+	value := []any{map[string]any{"id": 1}, map[string]any{"name": "x"}, "x"}
+
+	parsed, errs := schema.ValidateWithOpts(value, joi.ValidateOptions{StripUnknownArrays: true})
+	assert.Empty(t, errs)
+	assert.Equal(t, []any{map[string]any{"id": 1}}, parsed)
+
+	_, errs = schema.Validate(value)
+	assert.NotEmpty(t, errs)
+}
@@ -89,3 +89,31 @@ func TestBooleanSchema_Falsy(t *testing.T) {
 	_, errs3 := schema.ValidateWithOpts(1, joi.ValidateOptions{Path: joi.Ptr("field")})
 	assert.NotEmpty(t, errs3)
 }
+
+func TestBooleanSchema_Convert(t *testing.T) {
+	schema := joi.Boolean()
+
+	val, errs := schema.Validate("true")
+	assert.Empty(t, errs)
+	assert.Equal(t, true, val)
+
+	val, errs = schema.Validate("FALSE")
+	assert.Empty(t, errs)
+	assert.Equal(t, false, val)
+
+	_, errs = schema.Validate("yes")
+	assert.NotEmpty(t, errs)
+}
+
+func TestBooleanSchema_Strict(t *testing.T) {
+	schema := joi.Boolean().Truthy("Y").Strict()
+
+	_, errs := schema.Validate("true")
+	assert.NotEmpty(t, errs)
+
+	_, errs = schema.Validate("Y")
+	assert.NotEmpty(t, errs)
+
+	_, errs = schema.Validate(true)
+	assert.Empty(t, errs)
+}
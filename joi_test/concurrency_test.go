@@ -0,0 +1,74 @@
+package joi_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+// run these with `go test -race` (make test.race) to catch shared-state writes.
+
+var sharedUserSchema = joi.Object(map[string]joi.Schema{
+	"name": joi.String().Min(3),
+	"tags": joi.Array().Items(joi.String().Max(3)),
+})
+
+func TestConcurrency_SharedObjectSchema(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := fmt.Sprintf("req%d", i)
+			for j := 0; j < 50; j++ {
+				_, errs := sharedUserSchema.ValidateWithOpts(
+					map[string]any{"name": "ab", "tags": []any{"ok", "toolong"}},
+					joi.ValidateOptions{Path: joi.Ptr(path)},
+				)
+				if assert.Len(t, errs, 2) {
+					paths := []string{errs[0].Path, errs[1].Path}
+					assert.ElementsMatch(t, []string{path + ".name", path + ".tags[1]"}, paths)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrency_SharedArraySchema(t *testing.T) {
+	schema := joi.Array().Items(joi.Object(map[string]joi.Schema{
+		"id": joi.Number().Required(),
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := fmt.Sprintf("list%d", i)
+			for j := 0; j < 50; j++ {
+				_, errs := schema.ValidateWithOpts(
+					[]any{map[string]any{"id": float64(1)}, map[string]any{}},
+					joi.ValidateOptions{Path: joi.Ptr(path)},
+				)
+				if assert.Len(t, errs, 1) {
+					assert.Equal(t, path+"[1].id", errs[0].Path)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrency_SchemaDoesNotKeepPath(t *testing.T) {
+	schema := joi.String().Min(3)
+
+	_, errs := schema.ValidateWithOpts("ab", joi.ValidateOptions{Path: joi.Ptr("first")})
+	assert.Equal(t, "first", errs[0].Path)
+
+	_, errs = schema.Validate("ab")
+	assert.Equal(t, "", errs[0].Path)
+}
@@ -85,3 +85,25 @@ func TestDateSchema_Max_NonDateInput(t *testing.T) {
 	assert.NotEmpty(t, errs)
 	assert.Equal(t, 12345, val)
 }
+
+func TestDateSchema_Convert(t *testing.T) {
+	val, errs := joi.Date().Validate("2024-01-01")
+	assert.Empty(t, errs)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), val)
+}
+
+func TestDateSchema_Strict(t *testing.T) {
+	schema := joi.Date()
+	opts := joi.ValidateOptions{Convert: joi.Ptr(false)}
+
+	_, errs := schema.ValidateWithOpts("2024-01-01", opts)
+	assert.NotEmpty(t, errs)
+
+	_, errs = schema.ValidateWithOpts(int64(1735185600), opts)
+	assert.NotEmpty(t, errs)
+
+	now := time.Now()
+	val, errs := schema.ValidateWithOpts(now, opts)
+	assert.Empty(t, errs)
+	assert.Equal(t, now, val)
+}
@@ -0,0 +1,130 @@
+package joi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+type Order struct {
+	ID        int64             `json:"id"`
+	Customer  *Customer         `json:"customer"`
+	Items     []OrderItem       `json:"items"`
+	Status    string            `json:"status"`
+	Paid      bool              `json:"paid"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Labels    map[string]string `json:"labels"`
+	Extra     any               `json:"extra"`
+}
+
+type Customer struct {
+	Name string `json:"name"`
+}
+
+type OrderItem struct {
+	SKU   string  `json:"sku"`
+	Qty   uint8   `json:"qty"`
+	Price float32 `json:"price"`
+}
+
+func orderSchema() *joi.ObjectSchema {
+	return joi.Object(map[string]joi.Schema{
+		"id":        joi.Number().Integer().Required(),
+		"customer":  joi.Object(map[string]joi.Schema{"name": joi.String().Trim(true)}),
+		"items":     joi.Array().Items(joi.Object(nil).Unknown(true)),
+		"status":    joi.String().Default("pending"),
+		"paid":      joi.Boolean(),
+		"createdAt": joi.Date(),
+		"labels":    joi.Object(nil).Unknown(true),
+		"extra":     joi.Any[joi.Schema](),
+	})
+}
+
+func TestValidateInto_EmbeddedDominance(t *testing.T) {
+	type inner struct {
+		ID string `json:"id"`
+	}
+	type outer struct {
+		ID string `json:"id"`
+		inner
+	}
+
+	var out outer
+	assert.NoError(t, joi.ValidateInto(joi.Object(nil).Unknown(true), map[string]any{"id": "zz"}, &out))
+	assert.Equal(t, outer{ID: "zz"}, out)
+}
+
+func TestValidateInto(t *testing.T) {
+	input := map[string]any{
+		"id":        "42",
+		"customer":  map[string]any{"name": "  John  "},
+		"items":     []any{map[string]any{"sku": "A1", "qty": 2, "price": "9.5"}},
+		"paid":      "true",
+		"createdAt": "2024-01-02T03:04:05Z",
+		"labels":    map[string]any{"source": "web"},
+		"extra":     []any{1},
+	}
+
+	var order Order
+	err := joi.ValidateInto(orderSchema(), input, &order)
+	assert.Error(t, err, "price is not converted by an Unknown(true) object")
+
+	input["items"] = []any{map[string]any{"sku": "A1", "qty": 2, "price": 9.5}}
+	err = joi.ValidateInto(orderSchema(), input, &order)
+	assert.NoError(t, err)
+	assert.Equal(t, Order{
+		ID:        42,
+		Customer:  &Customer{Name: "John"},
+		Items:     []OrderItem{{SKU: "A1", Qty: 2, Price: 9.5}},
+		Status:    "pending",
+		Paid:      true,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Labels:    map[string]string{"source": "web"},
+		Extra:     []any{1},
+	}, order)
+}
+
+func TestValidateInto_DecodeErrors(t *testing.T) {
+	input := map[string]any{
+		"id":    1.5,
+		"items": []any{map[string]any{"sku": 1, "qty": 300}},
+	}
+	var order Order
+	err := joi.ValidateInto(joi.Object(nil).Unknown(true), input, &order)
+
+	errs, ok := err.(joi.ValidationErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "id", errs[0].Path)
+	assert.Equal(t, string(joi.AnyMsgDecode), errs[0].Type)
+	assert.Equal(t, "value cannot be decoded into int64", errs[0].Msg)
+	assert.Equal(t, "items[0].sku", errs[1].Path)
+	assert.Equal(t, "items[0].qty", errs[2].Path)
+	assert.Equal(t, []any{"items", 0, "qty"}, errs[2].Segments)
+	assert.Equal(t, Order{}, order, "out is untouched on error")
+}
+
+func TestValidateInto_ValidationErrors(t *testing.T) {
+	order := Order{Status: "kept"}
+	err := joi.ValidateInto(orderSchema(), map[string]any{}, &order)
+	assert.ErrorIs(t, err, joi.ErrValidation)
+	assert.Equal(t, "kept", order.Status)
+
+	assert.Error(t, joi.ValidateInto[Order](orderSchema(), map[string]any{}, nil))
+}
+
+func TestValidateInto_Scalars(t *testing.T) {
+	var n int
+	assert.NoError(t, joi.ValidateInto(joi.Number(), "12", &n))
+	assert.Equal(t, 12, n)
+
+	var names []string
+	assert.NoError(t, joi.ValidateInto(joi.Array().Items(joi.String().Upper()), []any{"a", "b"}, &names))
+	assert.Equal(t, []string{"A", "B"}, names)
+
+	var u Customer
+	err := joi.ValidateIntoWithOpts(joi.Object(nil).Unknown(true), map[string]any{"name": 1}, &u, joi.ValidateOptions{Path: joi.Ptr("body")})
+	assert.Equal(t, "body.name", err.(joi.ValidationErrors)[0].Path)
+}
@@ -0,0 +1,71 @@
+package joi_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+func validateUser(input map[string]any) error {
+	schema := joi.Object(map[string]joi.Schema{
+		"name":  joi.String().Min(3),
+		"email": joi.String().Email(),
+	})
+	_, errs := schema.Validate(input)
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("create user: %w", err)
+	}
+	return nil
+}
+
+func TestValidationErrors_Err(t *testing.T) {
+	var errs joi.ValidationErrors
+	assert.NoError(t, errs.Err())
+	assert.NoError(t, validateUser(map[string]any{"name": "john"}))
+}
+
+func TestValidationErrors_ErrorsAs(t *testing.T) {
+	err := validateUser(map[string]any{"name": "jo", "email": "nope"})
+	assert.Error(t, err)
+
+	var errs joi.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+
+	var single joi.ValidationError
+	assert.True(t, errors.As(err, &single))
+
+	assert.True(t, errors.Is(err, joi.ErrValidation))
+	assert.False(t, errors.Is(errors.New("other"), joi.ErrValidation))
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	errs := joi.ValidationErrors{
+		{Path: "a", Msg: "a is bad"},
+		{Path: "b", Msg: "b is bad"},
+	}
+	assert.Equal(t, `validation error at "a": a is bad; validation error at "b": b is bad`, errs.Error())
+	assert.Equal(t, `validation error at "a": a is bad`, errs[0].Error())
+}
+
+func TestValidationErrors_Helpers(t *testing.T) {
+	errs := joi.ValidationErrors{
+		{Path: "name", Type: string(joi.StringMsgMin)},
+		{Path: "name", Type: string(joi.StringMsgRegex)},
+		{Path: "age", Type: string(joi.NumberMsgBase)},
+	}
+
+	assert.Equal(t, "name", errs.First().Path)
+	assert.Nil(t, joi.ValidationErrors{}.First())
+
+	assert.Len(t, errs.ByPath("name"), 2)
+	assert.Empty(t, errs.ByPath("missing"))
+
+	assert.True(t, errs.Has(string(joi.NumberMsgBase)))
+	assert.False(t, errs.Has(string(joi.StringMsgMax)))
+
+	assert.Len(t, errs.Unwrap(), 3)
+}
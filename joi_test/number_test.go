@@ -1,6 +1,8 @@
 package joi_test
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/leandroluk/go-joi/joi"
@@ -108,3 +110,114 @@ func TestNumberSchema_Negative_NonNumberInput_WithNil(t *testing.T) {
 	assert.Empty(t, errs)
 	assert.Nil(t, val)
 }
+
+type customInt int
+
+func TestNumberSchema_Base_NumericKinds(t *testing.T) {
+	schema := joi.Number().Min(1).Max(100).Positive()
+
+	inputs := []any{
+		int(25), int8(25), int16(25), int32(25), int64(25),
+		uint(25), uint8(25), uint16(25), uint32(25), uint64(25),
+		float32(25), float64(25), customInt(25),
+	}
+	for _, in := range inputs {
+		val, errs := schema.Validate(in)
+		assert.Empty(t, errs, "%T", in)
+		assert.Equal(t, in, val, "%T", in)
+	}
+
+	_, errs := schema.Validate(int8(-1))
+	assert.Len(t, errs, 2)
+}
+
+func TestNumberSchema_Base_JSONNumber(t *testing.T) {
+	schema := joi.Number()
+
+	val, errs := schema.Validate(json.Number("42"))
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(42), val)
+
+	val, errs = schema.Validate(json.Number("4.5"))
+	assert.Empty(t, errs)
+	assert.Equal(t, 4.5, val)
+
+	val, errs = schema.Validate(json.Number("18446744073709551615"))
+	assert.Empty(t, errs)
+	assert.Equal(t, uint64(math.MaxUint64), val)
+
+	_, errs = schema.Validate(json.Number("abc"))
+	assert.NotEmpty(t, errs)
+}
+
+func TestNumberSchema_Base_RejectsNaN(t *testing.T) {
+	_, errs := joi.Number().Validate(math.NaN())
+	assert.NotEmpty(t, errs)
+}
+
+func TestNumberSchema_LargeIntegers(t *testing.T) {
+	schema := joi.Number().Max(9007199254740992) // 2^53
+
+	_, errs := schema.Validate(int64(9007199254740992))
+	assert.Empty(t, errs)
+
+	// float64(2^53 + 1) == 2^53, the comparison must not lose precision
+	_, errs = schema.Validate(int64(9007199254740993))
+	assert.NotEmpty(t, errs)
+
+	_, errs = joi.Number().Min(0).Validate(uint64(math.MaxUint64))
+	assert.Empty(t, errs)
+
+	_, errs = joi.Number().Negative().Validate(int64(math.MinInt64))
+	assert.Empty(t, errs)
+}
+
+func TestNumberSchema_Integer_NumericKinds(t *testing.T) {
+	schema := joi.Number().Integer()
+
+	val, errs := schema.Validate(int32(7))
+	assert.Empty(t, errs)
+	assert.Equal(t, int32(7), val)
+
+	val, errs = schema.Validate(float32(7))
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(7), val)
+
+	_, errs = schema.Validate(float32(7.5))
+	assert.NotEmpty(t, errs)
+
+	_, errs = schema.Validate(math.Inf(1))
+	assert.NotEmpty(t, errs)
+}
+
+func TestNumberSchema_Convert(t *testing.T) {
+	schema := joi.Number().Min(10)
+
+	val, errs := schema.Validate("42")
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(42), val)
+
+	val, errs = schema.Validate(" 42.5 ")
+	assert.Empty(t, errs)
+	assert.Equal(t, 42.5, val)
+
+	_, errs = schema.Validate("4")
+	assert.Len(t, errs, 1)
+
+	for _, bad := range []string{"", "abc", "0x10", "Inf", "NaN", "1_000"} {
+		_, errs = schema.Validate(bad)
+		assert.NotEmpty(t, errs, bad)
+	}
+}
+
+func TestNumberSchema_Strict(t *testing.T) {
+	schema := joi.Number().Integer().Strict()
+
+	_, errs := schema.Validate("42")
+	assert.NotEmpty(t, errs)
+
+	// Integer only checks in strict mode, it does not convert to int64
+	val, errs := schema.Validate(float64(42))
+	assert.Empty(t, errs)
+	assert.Equal(t, float64(42), val)
+}
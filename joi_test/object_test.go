@@ -1,6 +1,8 @@
 package joi_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/leandroluk/go-joi/joi"
@@ -105,3 +107,506 @@ func TestObjectSchema_Rules_NonMapBranches(t *testing.T) {
 	assert.NotEmpty(t, errs)
 	assert.Contains(t, errs[0].String(), "must be an object")
 }
+
+func TestObjectSchema_AbortEarly(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"a": joi.String().Min(3).Max(1),
+		"b": joi.String(),
+	})
+
+	input := map[string]any{"a": "ab", "b": 1, "c": true}
+
+	_, errs := schema.Validate(input)
+	assert.Len(t, errs, 4)
+
+	_, errs = schema.ValidateWithOpts(input, joi.ValidateOptions{AbortEarly: true})
+	assert.Len(t, errs, 1)
+}
+
+func TestObjectSchema_MaxErrors(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"a": joi.String()})
+
+	input := map[string]any{"a": 1, "x": 1, "y": 2, "z": 3}
+
+	_, errs := schema.ValidateWithOpts(input, joi.ValidateOptions{MaxErrors: 2})
+	assert.Len(t, errs, 2)
+}
+
+func TestObjectSchema_StructuredErrors(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"tags": joi.Array().Items(joi.String().Min(3)),
+	})
+
+	_, errs := schema.Validate(map[string]any{"tags": []any{"abc", "x"}, "extra": 1})
+	assert.Len(t, errs, 2)
+
+	byType := map[string]joi.ValidationError{}
+	for _, e := range errs {
+		byType[e.Type] = e
+	}
+
+	minErr := byType["string_min"]
+	assert.Equal(t, "tags[1]", minErr.Path)
+	assert.Equal(t, []any{"tags", 1}, minErr.Segments)
+	assert.Equal(t, "x", minErr.Value)
+	assert.Equal(t, 3, minErr.Context["limit"])
+
+	unknownErr := byType["object_unknown"]
+	assert.Equal(t, "extra", unknownErr.Path)
+	assert.Equal(t, []any{"extra"}, unknownErr.Segments)
+	assert.Equal(t, 1, unknownErr.Value)
+	assert.Equal(t, "extra", unknownErr.Context["key"])
+}
+
+func TestObjectSchema_Keys_DeclarationOrder(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("zeta", joi.String()),
+		joi.Key("alpha", joi.String()),
+		joi.Key("mid", joi.String()),
+	)
+
+	input := map[string]any{"zeta": 1, "alpha": 2, "mid": 3, "y": 0, "b": 0, "x": 0}
+	want := []string{"zeta", "alpha", "mid", "b", "x", "y"}
+
+	for range 20 {
+		_, errs := schema.Validate(input)
+		got := make([]string, len(errs))
+		for i, e := range errs {
+			got[i] = e.Path
+		}
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestObjectSchema_Keys_MapFieldsAreSorted(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"c": joi.String(),
+		"a": joi.String(),
+		"b": joi.String(),
+	})
+
+	for range 20 {
+		_, errs := schema.Validate(map[string]any{"a": 1, "b": 1, "c": 1})
+		assert.Equal(t, []string{"a", "b", "c"}, []string{errs[0].Path, errs[1].Path, errs[2].Path})
+	}
+}
+
+func TestObjectSchema_Keys_Override(t *testing.T) {
+	base := joi.Object(nil).Keys(joi.Key("a", joi.String()), joi.Key("b", joi.String()))
+	extended := base.Keys(joi.Key("a", joi.Number()), joi.Key("c", joi.String()))
+
+	_, errs := base.Validate(map[string]any{"a": "x", "b": "y"})
+	assert.Empty(t, errs)
+
+	_, errs = extended.Validate(map[string]any{"a": "x", "b": 1, "c": 1})
+	assert.Equal(t, []string{"a", "b", "c"}, []string{errs[0].Path, errs[1].Path, errs[2].Path})
+
+	_, errs = base.Validate(map[string]any{"c": "z"})
+	assert.True(t, errs.Has(string(joi.ObjectMsgUnknown)))
+}
+
+func TestObjectSchema_GoBuiltNumbers(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"username": joi.String().Min(3).Max(20).Required(),
+		"age":      joi.Number().Min(18).Required(),
+	})
+
+	_, errs := schema.Validate(map[string]any{"username": "john_doe", "age": 25})
+	assert.Empty(t, errs)
+}
+
+func TestObjectSchema_StrictPropagatesToChildren(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"age": joi.Number()})
+
+	parsed, errs := schema.Validate(map[string]any{"age": "42"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"age": int64(42)}, parsed)
+
+	_, errs = schema.Strict().Validate(map[string]any{"age": "42"})
+	assert.NotEmpty(t, errs)
+}
+
+func contactSchema() *joi.ObjectSchema {
+	return joi.Object(map[string]joi.Schema{}).Unknown(true)
+}
+
+func TestObjectSchema_And(t *testing.T) {
+	schema := contactSchema().And("lat", "lng")
+
+	_, errs := schema.Validate(map[string]any{"lat": 1, "lng": 2})
+	assert.Empty(t, errs)
+	_, errs = schema.Validate(map[string]any{})
+	assert.Empty(t, errs)
+
+	_, errs = schema.ValidateWithOpts(map[string]any{"lat": 1}, joi.ValidateOptions{Path: joi.Ptr("pos")})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgAnd), errs[0].Type)
+	assert.Equal(t, "pos.lng", errs[0].Path)
+	assert.Equal(t, []any{"pos", "lng"}, errs[0].Segments)
+	assert.Equal(t, "value contains [lat] without its required peers [lng]", errs[0].Msg)
+}
+
+func TestObjectSchema_Or(t *testing.T) {
+	schema := contactSchema().Or("email", "phone")
+
+	_, errs := schema.Validate(map[string]any{"phone": "123"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"email": nil})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgMissing), errs[0].Type)
+	assert.Equal(t, "", errs[0].Path)
+	assert.Equal(t, "value must contain at least one of [email, phone]", errs[0].Msg)
+}
+
+func TestObjectSchema_Xor(t *testing.T) {
+	schema := contactSchema().Xor("email", "phone")
+
+	_, errs := schema.Validate(map[string]any{"email": "a@b.com"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgMissing), errs[0].Type)
+
+	_, errs = schema.Validate(map[string]any{"email": "a@b.com", "phone": "123"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgXor), errs[0].Type)
+	assert.Equal(t, "phone", errs[0].Path)
+}
+
+func TestObjectSchema_Oxor(t *testing.T) {
+	schema := contactSchema().Oxor("email", "phone")
+
+	_, errs := schema.Validate(map[string]any{})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"email": "a@b.com", "phone": "123"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgOxor), errs[0].Type)
+}
+
+func TestObjectSchema_Nand(t *testing.T) {
+	schema := contactSchema().Nand("coupon", "discount", "voucher")
+
+	_, errs := schema.Validate(map[string]any{"coupon": "x", "discount": 1})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"coupon": "x", "discount": 1, "voucher": "y"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgNand), errs[0].Type)
+	assert.Equal(t, "coupon", errs[0].Path)
+	assert.Equal(t, "'coupon' must not exist simultaneously with [discount, voucher]", errs[0].Msg)
+}
+
+func TestObjectSchema_With(t *testing.T) {
+	schema := contactSchema().With("password", "username", "email")
+
+	_, errs := schema.Validate(map[string]any{"username": "john"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"password": "x", "username": "john"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgWith), errs[0].Type)
+	assert.Equal(t, "password", errs[0].Path)
+	assert.Equal(t, "'password' missing required peer 'email'", errs[0].Msg)
+}
+
+func TestObjectSchema_Without(t *testing.T) {
+	schema := contactSchema().Without("guest", "userId")
+
+	_, errs := schema.Validate(map[string]any{"userId": 1})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"guest": true, "userId": 1})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgWithout), errs[0].Type)
+	assert.Equal(t, "guest", errs[0].Path)
+	assert.Equal(t, "'guest' conflict with forbidden peer 'userId'", errs[0].Msg)
+}
+
+func TestObjectSchema_PeersSeeParsedKeys(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"a": joi.String(),
+		"b": joi.String().Default("d"),
+	}).Or("a", "b")
+
+	parsed, errs := schema.Validate(map[string]any{})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"b": "d"}, parsed)
+
+	stripped := joi.Object(map[string]joi.Schema{"a": joi.String()}).StripUnknown().Max(1)
+	parsed, errs = stripped.Validate(map[string]any{"a": "x", "junk": 1})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"a": "x"}, parsed)
+}
+
+func TestObjectSchema_Pattern(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"default": joi.String()}).
+		Pattern(regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`), joi.String().Min(2))
+
+	parsed, errs := schema.Validate(map[string]any{"default": "hi", "en-US": "hello", "pt-BR": "olá"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"default": "hi", "en-US": "hello", "pt-BR": "olá"}, parsed)
+
+	_, errs = schema.Validate(map[string]any{"en-US": "x", "english": "hello"})
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "en-US", errs[0].Path)
+	assert.Equal(t, string(joi.StringMsgMin), errs[0].Type)
+	assert.Equal(t, "english", errs[1].Path)
+	assert.Equal(t, string(joi.ObjectMsgUnknown), errs[1].Type)
+
+	_, errs = schema.Unknown(true).Validate(map[string]any{"english": "hello"})
+	assert.Empty(t, errs)
+}
+
+func TestObjectSchema_PatternKeySchema(t *testing.T) {
+	schema := joi.Object(nil).Pattern(joi.String().Min(3), joi.Number())
+
+	parsed, errs := schema.Validate(map[string]any{"cpu": "1.5"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"cpu": 1.5}, parsed)
+
+	_, errs = schema.Validate(map[string]any{"io": 1})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgUnknown), errs[0].Type)
+}
+
+func TestObjectSchema_PatternFallthrough(t *testing.T) {
+	first := joi.Object(nil).
+		Pattern(`^x`, joi.String().Min(2)).
+		Pattern(`^x_`, joi.String().Max(3))
+	_, errs := first.Validate(map[string]any{"x_a": "long value"})
+	assert.Empty(t, errs)
+
+	all := joi.Object(nil).
+		Pattern(`^x`, joi.String().Min(2), joi.PatternOptions{Fallthrough: true}).
+		Pattern(`^x_`, joi.String().Max(3))
+	_, errs = all.Validate(map[string]any{"x_a": "long value"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgMax), errs[0].Type)
+}
+
+func TestObjectSchema_PatternInvalidKey(t *testing.T) {
+	assert.Panics(t, func() { joi.Object(nil).Pattern(42, nil) })
+}
+
+func TestObjectSchema_Rename(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"username": joi.String().Required()}).
+		Rename("user_name", "username")
+
+	parsed, errs := schema.Validate(map[string]any{"user_name": "john"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"username": "john"}, parsed)
+
+	input := map[string]any{"user_name": "john", "username": "jane"}
+	_, errs = schema.Validate(input)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, string(joi.ObjectMsgRenameOverride), errs[0].Type)
+	assert.Equal(t, "user_name", errs[0].Path)
+	assert.Equal(t, "value cannot rename 'user_name' because override is disabled and target 'username' exists", errs[0].Msg)
+	assert.True(t, errs.Has(string(joi.ObjectMsgUnknown)))
+	assert.Equal(t, map[string]any{"user_name": "john", "username": "jane"}, input, "input must not be mutated")
+
+	override := joi.Object(map[string]joi.Schema{"username": joi.String().Required()}).
+		Rename("user_name", "username", joi.RenameOptions{Override: true})
+	parsed, errs = override.Validate(input)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"username": "john"}, parsed)
+}
+
+func TestObjectSchema_RenameBeforeRules(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"username": joi.String(), "email": joi.String()}).
+		Rename("user_name", "username").
+		With("username", "email")
+
+	_, errs := schema.Validate(map[string]any{"user_name": "x"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgWith), errs[0].Type)
+	assert.Equal(t, "username", errs[0].Path)
+
+	parsed, errs := schema.Validate(map[string]any{"user_name": "x", "email": "x@example.com"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"username": "x", "email": "x@example.com"}, parsed)
+
+	custom := joi.Object(map[string]joi.Schema{"username": joi.String()}).
+		Rename("user_name", "username").
+		Custom(func(path string, value any) *joi.ValidationError {
+			if _, ok := value.(map[string]any)["username"]; !ok {
+				return &joi.ValidationError{Path: path}
+			}
+			return nil
+		})
+	_, errs = custom.Validate(map[string]any{"user_name": "x"})
+	assert.Empty(t, errs)
+}
+
+func TestObjectSchema_RenameOptions(t *testing.T) {
+	alias := joi.Object(nil).Unknown(true).Rename("a", "b", joi.RenameOptions{Alias: true})
+	parsed, errs := alias.Validate(map[string]any{"a": 1})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"a": 1, "b": 1}, parsed)
+
+	multiple := joi.Object(nil).Unknown(true).Rename("a", "c").Rename("b", "c")
+	_, errs = multiple.Validate(map[string]any{"a": 1, "b": 2})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgRenameMultiple), errs[0].Type)
+
+	multiple = joi.Object(nil).Unknown(true).Rename("a", "c").Rename("b", "c", joi.RenameOptions{Multiple: true})
+	parsed, errs = multiple.Validate(map[string]any{"a": 1, "b": 2})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"c": 2}, parsed)
+
+	ignore := joi.Object(nil).Unknown(true).Rename("a", "b", joi.RenameOptions{IgnoreUndefined: true})
+	parsed, errs = ignore.Validate(map[string]any{"a": nil})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"a": nil}, parsed)
+}
+
+func TestObjectSchema_RenameRegex(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"name": joi.String(), "age": joi.Number()}).
+		Rename(regexp.MustCompile(`^user_(\w+)$`), "$1")
+
+	parsed, errs := schema.Validate(map[string]any{"user_name": "john", "user_age": 30})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"name": "john", "age": 30}, parsed)
+
+	assert.Panics(t, func() { joi.Object(nil).Rename(1, "a") })
+}
+
+func TestObjectSchema_Strip(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("password", joi.String().Required()),
+		joi.Key("confirmPassword", joi.String().Valid([]any{joi.Ref("password")}).Strip()),
+	)
+
+	parsed, errs := schema.Validate(map[string]any{"password": "secret", "confirmPassword": "secret"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"password": "secret"}, parsed)
+
+	_, errs = schema.Validate(map[string]any{"password": "secret", "confirmPassword": "other"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "confirmPassword", errs[0].Path)
+
+	patterns := joi.Object(nil).Pattern(`^_`, joi.String().Strip())
+	parsed, errs = patterns.Validate(map[string]any{"_csrf": "token"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{}, parsed)
+}
+
+func TestObjectSchema_StripUnknown(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{"name": joi.String()}).StripUnknown()
+
+	parsed, errs := schema.Validate(map[string]any{"name": "john", "admin": true})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"name": "john"}, parsed)
+
+	parsed, errs = schema.Unknown(true).Validate(map[string]any{"name": "john", "admin": true})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"name": "john", "admin": true}, parsed)
+}
+
+func TestObjectSchema_UnknownOptions(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"name":    joi.String(),
+		"address": joi.Object(map[string]joi.Schema{"city": joi.String()}),
+		"tags":    joi.Object(nil).Unknown(false),
+	})
+	value := map[string]any{
+		"name":    "john",
+		"admin":   true,
+		"address": map[string]any{"city": "Recife", "zip": "50000"},
+		"tags":    map[string]any{"vip": true},
+	}
+
+	parsed, errs := schema.ValidateWithOpts(value, joi.ValidateOptions{StripUnknown: true})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{
+		"name":    "john",
+		"address": map[string]any{"city": "Recife"},
+		"tags":    map[string]any{},
+	}, parsed)
+
+	parsed, errs = schema.ValidateWithOpts(value, joi.ValidateOptions{AllowUnknown: true})
+	assert.Len(t, errs, 1, "a schema setting Unknown overrides AllowUnknown")
+	assert.Equal(t, "tags.vip", errs[0].Path)
+	assert.Equal(t, true, parsed.(map[string]any)["admin"])
+	assert.Equal(t, "50000", parsed.(map[string]any)["address"].(map[string]any)["zip"])
+
+	_, errs = schema.Validate(value)
+	assert.Len(t, errs, 3)
+}
+
+func TestObjectSchema_KeyPresence(t *testing.T) {
+	create := joi.Object(nil).Keys(
+		joi.Key("id", joi.Number().Forbidden()),
+		joi.Key("name", joi.String()),
+		joi.Key("nick", joi.String().Optional()),
+	)
+
+	_, errs := create.Validate(map[string]any{"name": "john"})
+	assert.Empty(t, errs)
+
+	_, errs = create.Validate(map[string]any{"id": 1, "name": "john"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "id", errs[0].Path)
+	assert.Equal(t, "value is not allowed", errs[0].Msg)
+
+	_, errs = create.ValidateWithOpts(map[string]any{}, joi.ValidateOptions{Presence: joi.PresenceRequired})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "name", errs[0].Path)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+
+	strict := joi.Object(nil).Presence(joi.PresenceRequired).Keys(
+		joi.Key("name", joi.String()),
+		joi.Key("address", joi.Object(nil).Keys(joi.Key("city", joi.String()))),
+	)
+	_, errs = strict.Validate(map[string]any{"address": map[string]any{}})
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "name", errs[0].Path)
+	assert.Equal(t, "address.city", errs[1].Path)
+	_, errs = strict.Validate(nil)
+	assert.Empty(t, errs, "Presence applies to the keys, not to the object itself")
+}
+
+func TestObjectSchema_Defaults(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("firstName", joi.String()),
+		joi.Key("lastName", joi.String()),
+		joi.Key("role", joi.String().Default("user")),
+		joi.Key("fullName", joi.String().DefaultFunc(func(parent map[string]any, h joi.DefaultHelpers) (any, error) {
+			return fmt.Sprintf("%v %v", parent["firstName"], h.Ref("lastName")), nil
+		})),
+		joi.Key("nick", joi.String()),
+		joi.Key("secret", joi.String().Default("x").Strip()),
+	)
+
+	parsed, errs := schema.Validate(map[string]any{"firstName": "John", "lastName": "Doe"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{
+		"firstName": "John",
+		"lastName":  "Doe",
+		"role":      "user",
+		"fullName":  "John Doe",
+	}, parsed)
+
+	parsed, _ = schema.Validate(map[string]any{"role": "admin"})
+	assert.Equal(t, "admin", parsed.(map[string]any)["role"])
+}
+
+func TestObjectSchema_DefaultFuncSeesParsedSiblings(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("first", joi.String().Trim(true).Default("guest")),
+		joi.Key("display", joi.String().DefaultFunc(func(parent map[string]any, h joi.DefaultHelpers) (any, error) {
+			return fmt.Sprintf("%v!", parent["first"]), nil
+		})),
+	)
+
+	parsed, errs := schema.Validate(map[string]any{})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"first": "guest", "display": "guest!"}, parsed)
+
+	parsed, errs = schema.Validate(map[string]any{"first": "  Jo  "})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"first": "Jo", "display": "Jo!"}, parsed)
+}
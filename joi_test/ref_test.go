@@ -0,0 +1,189 @@
+package joi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRef_DateSibling(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("startDate", joi.Date().Required()),
+		joi.Key("endDate", joi.Date().Min(joi.Ref("startDate"))),
+	)
+
+	_, errs := schema.Validate(map[string]any{"startDate": "2024-01-10", "endDate": "2024-01-20"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"startDate": "2024-01-10", "endDate": "2024-01-01"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "endDate", errs[0].Path)
+	assert.Equal(t, string(joi.DateMsgMin), errs[0].Type)
+	assert.Equal(t, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), errs[0].Context["limit"])
+}
+
+func TestRef_ConvertedSibling(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("min", joi.Number()),
+		joi.Key("value", joi.Number().Min(joi.Ref("min"))),
+	)
+
+	val, errs := schema.Validate(map[string]any{"min": "5", "value": "7"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"min": int64(5), "value": int64(7)}, val)
+
+	_, errs = schema.Validate(map[string]any{"min": "5", "value": "3"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.NumberMsgMin), errs[0].Type)
+}
+
+func TestRef_ReferencedSiblingFirst(t *testing.T) {
+	// "confirm" sorts before "password" but is validated after it
+	schema := joi.Object(map[string]joi.Schema{
+		"confirm":  joi.String().Valid([]any{joi.Ref("password")}),
+		"password": joi.String().Trim(true),
+	})
+
+	val, errs := schema.Validate(map[string]any{"confirm": "s3cr3t", "password": "  s3cr3t  "})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"confirm": "s3cr3t", "password": "s3cr3t"}, val)
+}
+
+func TestRef_ValidSibling(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("password", joi.String().Min(8)),
+		joi.Key("confirmPassword", joi.String().Valid([]any{joi.Ref("password")})),
+	)
+
+	_, errs := schema.Validate(map[string]any{"password": "s3cr3t!!", "confirmPassword": "s3cr3t!!"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"password": "s3cr3t!!", "confirmPassword": "other"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "confirmPassword", errs[0].Path)
+}
+
+func TestRef_Context(t *testing.T) {
+	schema := joi.Array().Max(joi.Ref("$maxItems"))
+	opts := joi.ValidateOptions{Context: map[string]any{"maxItems": 2}}
+
+	_, errs := schema.ValidateWithOpts([]any{1, 2}, opts)
+	assert.Empty(t, errs)
+
+	_, errs = schema.ValidateWithOpts([]any{1, 2, 3}, opts)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Msg, "less than or equal to 2 items")
+}
+
+func TestRef_ContextLargeFloatLimit(t *testing.T) {
+	schema := joi.Array().Max(joi.Ref("$maxItems"))
+
+	// numbers decoded from JSON are float64
+	_, errs := schema.ValidateWithOpts([]any{1, 2}, joi.ValidateOptions{Context: map[string]any{"maxItems": float64(1 << 40)}})
+	assert.Empty(t, errs)
+
+	_, errs = schema.ValidateWithOpts([]any{1, 2}, joi.ValidateOptions{Context: map[string]any{"maxItems": 1e300}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgRef), errs[0].Type)
+}
+
+func TestRef_InvalidLiteralLimits(t *testing.T) {
+	assert.PanicsWithValue(t, "joi: string_min limit must be a non-negative integer or a Reference, got -1", func() { joi.String().Min(-1) })
+	assert.Panics(t, func() { joi.String().Min(2.5) })
+	assert.Panics(t, func() { joi.Array().Max("3") })
+	assert.Panics(t, func() { joi.Object(nil).Length(nil) })
+	assert.Panics(t, func() { joi.Number().Max("10") })
+	assert.Panics(t, func() { joi.Date().Min("tomorrow") })
+
+	assert.NotPanics(t, func() {
+		joi.String().Min(uint8(2)).Max(2.0)
+		joi.Number().Min(-1.5)
+		joi.Date().Min("2024-01-10").Max(time.Now())
+		joi.Array().Max(joi.Ref("$maxItems"))
+	})
+
+	type negative struct {
+		Name string `joi:"min=-1"`
+	}
+	_, err := joi.StructSchema(negative{})
+	assert.ErrorContains(t, err, "is negative")
+}
+
+func TestRef_NestedAndAncestors(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"limits": joi.Object(map[string]joi.Schema{"name": joi.Number()}),
+		"max":    joi.Number(),
+		"user": joi.Object(map[string]joi.Schema{
+			"name": joi.String().Max(joi.Ref("...limits.name")),
+			"age":  joi.Number().Max(joi.Ref("/max")),
+		}),
+	})
+
+	input := map[string]any{
+		"limits": map[string]any{"name": 3},
+		"max":    30,
+		"user":   map[string]any{"name": "john", "age": 40},
+	}
+	_, errs := schema.Validate(input)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "user.age", errs[0].Path)
+	assert.Equal(t, 30, errs[0].Context["limit"])
+	assert.Equal(t, "user.name", errs[1].Path)
+	assert.Equal(t, 3, errs[1].Context["limit"])
+}
+
+func TestRef_Self(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"max":  joi.Number(),
+		"list": joi.Array(),
+	}).Max(joi.Ref(".max"))
+
+	_, errs := schema.Validate(map[string]any{"max": 2, "list": []any{}})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"max": 1, "list": []any{}})
+	assert.Len(t, errs, 1)
+}
+
+func TestRef_InvalidReferencedValue(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"min": joi.Any[joi.Schema](),
+		"qty": joi.Number().Min(joi.Ref("min")),
+	})
+
+	_, errs := schema.Validate(map[string]any{"min": "abc", "qty": 1})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgRef), errs[0].Type)
+	assert.Equal(t, "value limit references min which must be a number", errs[0].Msg)
+
+	_, errs = schema.Validate(map[string]any{"qty": 1})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgRef), errs[0].Type)
+}
+
+func TestRef_When(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"a": joi.Number().When(joi.Ref("$strict"), joi.WhenOpts{Then: joi.Number().Integer()}),
+	})
+
+	_, errs := schema.Validate(map[string]any{"a": 1.5})
+	assert.Empty(t, errs)
+
+	_, errs = schema.ValidateWithOpts(map[string]any{"a": 1.5}, joi.ValidateOptions{Context: map[string]any{"strict": true}})
+	assert.Len(t, errs, 1)
+}
+
+func TestRef_LiteralLimitsStillWork(t *testing.T) {
+	limit := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, errs := joi.Date().Max(limit).Validate("2026-01-01")
+	assert.Len(t, errs, 1)
+
+	_, errs = joi.Number().Max(2.5).Validate(3)
+	assert.Len(t, errs, 1)
+
+	_, errs = joi.String().Length(uint8(2)).Validate("ab")
+	assert.Empty(t, errs)
+}
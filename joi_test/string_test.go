@@ -2,6 +2,7 @@ package joi_test
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/leandroluk/go-joi/joi"
@@ -124,8 +125,62 @@ func TestStringSchema_Email_EmptyString(t *testing.T) {
 	assert.Equal(t, "", val)
 }
 
+func TestStringSchema_Email_Options(t *testing.T) {
+	cases := []struct {
+		opts  joi.EmailOptions
+		value string
+		valid bool
+	}{
+		{joi.EmailOptions{}, "user@localhost", false},
+		{joi.EmailOptions{}, "first.last+tag@sub.example.com", true},
+		{joi.EmailOptions{}, "a..b@example.com", false},
+		{joi.EmailOptions{}, "user@example.com.", false},
+		{joi.EmailOptions{MinDomainSegments: 1}, "user@localhost", true},
+		{joi.EmailOptions{}, "John Doe <john@example.com>", false},
+		{joi.EmailOptions{AllowDisplayName: true}, "John Doe <john@example.com>", true},
+		{joi.EmailOptions{}, "a@example.com, b@example.org", false},
+		{joi.EmailOptions{Multiple: true}, "a@example.com, b@example.org", true},
+		{joi.EmailOptions{Multiple: true}, "a@example.com, b@", false},
+		{joi.EmailOptions{Multiple: true, Separator: ";"}, "a@example.com;b@example.org", true},
+		{joi.EmailOptions{AllowTLDs: []string{"com"}}, "user@example.org", false},
+		{joi.EmailOptions{AllowTLDs: []string{"com"}}, "user@example.com", true},
+		{joi.EmailOptions{DenyTLDs: []string{"test"}}, "user@example.test", false},
+		{joi.EmailOptions{}, "josé@exemplo.com.br", true},
+		{joi.EmailOptions{}, "user@münchen.de", true},
+		{joi.EmailOptions{AllowUnicode: joi.Ptr(false)}, "josé@exemplo.com.br", false},
+		{joi.EmailOptions{AllowUnicode: joi.Ptr(false)}, "user@münchen.de", false},
+		{joi.EmailOptions{}, strings.Repeat("a", 65) + "@example.com", false},
+		{joi.EmailOptions{IgnoreLength: true}, strings.Repeat("a", 65) + "@example.com", true},
+	}
+	for _, c := range cases {
+		_, errs := joi.String().EmailWithOptions(c.opts).Validate(c.value)
+		assert.Equal(t, c.valid, len(errs) == 0, "%+v %q", c.opts, c.value)
+	}
+}
+
+func TestStringSchema_Email_CustomMessage(t *testing.T) {
+	_, errs := joi.String().Email("{{#label}} is not an email").Validate("nope")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "value is not an email", errs[0].Msg)
+}
+
+func TestStringSchema_Email_ErrorTypes(t *testing.T) {
+	schema := joi.String().EmailWithOptions(joi.EmailOptions{Multiple: true})
+
+	_, errs := schema.Validate("ok@example.com, bad local@example.com")
+	assert.Equal(t, string(joi.StringMsgEmailLocal), errs[0].Type)
+	assert.Equal(t, "value must be a valid email, 'bad local@example.com' has an invalid local part", errs[0].Msg)
+
+	_, errs = schema.Validate("user@localhost")
+	assert.Equal(t, string(joi.StringMsgEmailDomain), errs[0].Type)
+	assert.Equal(t, "user@localhost", errs[0].Context["email"])
+
+	_, errs = schema.Validate("no-at-sign")
+	assert.Equal(t, string(joi.StringMsgEmail), errs[0].Type)
+}
+
 func TestStringSchema_Trim(t *testing.T) {
-	schema := joi.String().Trim()
+	schema := joi.String().Trim(true)
 
 	val, errs := schema.ValidateWithOpts("  abc  ", joi.ValidateOptions{Path: joi.Ptr("field")})
 	assert.Empty(t, errs)
@@ -133,7 +188,7 @@ func TestStringSchema_Trim(t *testing.T) {
 }
 
 func TestStringSchema_Trim_NonStringWithNil(t *testing.T) {
-	schema := joi.String().Trim()
+	schema := joi.String().Trim(true)
 	val, errs := schema.ValidateWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("field")})
 	assert.Empty(t, errs)
 	assert.Nil(t, val)
@@ -143,14 +198,14 @@ func TestStringSchema_Lower(t *testing.T) {
 	schema := joi.String().Lower()
 
 	val, errs1 := schema.ValidateWithOpts("ABC", joi.ValidateOptions{Path: joi.Ptr("field")})
-	assert.NotEmpty(t, errs1)
+	assert.Empty(t, errs1)
 	assert.Equal(t, "abc", val)
 
-	val, errs2 := schema.ValidateWithOpts("ABC", joi.ValidateOptions{Path: joi.Ptr("field")}) // revalida com mesmo input?
-	_ = val
-	_ = errs2
+	val, errs2 := schema.ValidateWithOpts("ABC", joi.ValidateOptions{Path: joi.Ptr("field"), Convert: joi.Ptr(false)})
+	assert.NotEmpty(t, errs2)
+	assert.Equal(t, "ABC", val)
 
-	val, errs3 := schema.ValidateWithOpts("abc", joi.ValidateOptions{Path: joi.Ptr("field")})
+	val, errs3 := schema.Strict().ValidateWithOpts("abc", joi.ValidateOptions{Path: joi.Ptr("field")})
 	assert.Empty(t, errs3)
 	assert.Equal(t, "abc", val)
 }
@@ -166,11 +221,15 @@ func TestStringSchema_Upper(t *testing.T) {
 	schema := joi.String().Upper()
 
 	val, errs1 := schema.ValidateWithOpts("abc", joi.ValidateOptions{Path: joi.Ptr("field")})
-	assert.NotEmpty(t, errs1)
+	assert.Empty(t, errs1)
 	assert.Equal(t, "ABC", val)
 
-	val, errs2 := schema.ValidateWithOpts("ABC", joi.ValidateOptions{Path: joi.Ptr("field")})
-	assert.Empty(t, errs2)
+	val, errs2 := schema.Strict().ValidateWithOpts("abc", joi.ValidateOptions{Path: joi.Ptr("field")})
+	assert.NotEmpty(t, errs2)
+	assert.Equal(t, "abc", val)
+
+	val, errs3 := schema.Strict().ValidateWithOpts("ABC", joi.ValidateOptions{Path: joi.Ptr("field")})
+	assert.Empty(t, errs3)
 	assert.Equal(t, "ABC", val)
 }
 
@@ -180,3 +239,390 @@ func TestStringSchema_Upper_NonStringWithNil(t *testing.T) {
 	assert.Empty(t, errs)
 	assert.Nil(t, val)
 }
+
+func TestStringSchema_BuilderDoesNotAlias(t *testing.T) {
+	base := joi.String().Trim(true)
+	a := base.Min(3)
+	b := base.Max(5)
+
+	_, errs := a.Validate("abcdefgh")
+	assert.Empty(t, errs)
+
+	_, errs = b.Validate("ab")
+	assert.Empty(t, errs)
+
+	_, errs = base.Validate("a")
+	assert.Empty(t, errs)
+}
+
+func TestStringSchema_Clone(t *testing.T) {
+	base := joi.String().Min(3)
+	clone := base.Clone()
+	assert.NotSame(t, base, clone)
+
+	_, errs := clone.Validate("ab")
+	assert.NotEmpty(t, errs)
+}
+
+func TestStringSchema_Trim_Strict(t *testing.T) {
+	schema := joi.String().Trim(true)
+
+	val, errs := schema.ValidateWithOpts("  abc  ", joi.ValidateOptions{Convert: joi.Ptr(false)})
+	assert.NotEmpty(t, errs)
+	assert.Equal(t, "  abc  ", val)
+	assert.Contains(t, errs[0].Msg, "must be a trimmed string")
+
+	_, errs = schema.ValidateWithOpts("abc", joi.ValidateOptions{Convert: joi.Ptr(false)})
+	assert.Empty(t, errs)
+}
+
+func TestStringSchema_Trim_Disabled(t *testing.T) {
+	base := joi.String().Trim(true)
+	schema := base.Trim(false)
+
+	val, errs := schema.Validate("  abc  ")
+	assert.Empty(t, errs)
+	assert.Equal(t, "  abc  ", val)
+
+	val, _ = base.Validate("  abc  ")
+	assert.Equal(t, "abc", val)
+}
+
+func TestStringSchema_Trim_KeepsPosition(t *testing.T) {
+	base := joi.String().Trim(true).Min(3)
+	again := base.Trim(true)
+
+	_, errs := base.Validate("  ab  ")
+	assert.Len(t, errs, 1)
+
+	val, errs := again.Validate("  ab  ")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgMin), errs[0].Type)
+	assert.Equal(t, "ab", val)
+
+	_, errs = base.Trim(true, "no spaces").Strict().Validate(" abc")
+	assert.Equal(t, "no spaces", errs[0].Msg)
+}
+
+func TestStringSchema_Case(t *testing.T) {
+	val, errs := joi.String().Case(joi.CaseLower).Validate("ABC")
+	assert.Empty(t, errs)
+	assert.Equal(t, "abc", val)
+
+	val, errs = joi.String().Case("upper").Strict().Validate("abc")
+	assert.Equal(t, string(joi.StringMsgUpper), errs[0].Type)
+	assert.Equal(t, "abc", val)
+
+	assert.Panics(t, func() { joi.String().Case("title") })
+}
+
+func TestStringSchema_Replace(t *testing.T) {
+	schema := joi.String().Replace(regexp.MustCompile(`\s+`), " ")
+
+	val, errs := schema.Validate("a  b\tc")
+	assert.Empty(t, errs)
+	assert.Equal(t, "a b c", val)
+
+	val, errs = schema.ValidateWithOpts("a  b", joi.ValidateOptions{Convert: joi.Ptr(false)})
+	assert.Equal(t, string(joi.StringMsgReplace), errs[0].Type)
+	assert.Equal(t, `value must not contain matches of \s+`, errs[0].Msg)
+	assert.Equal(t, "a  b", val)
+
+	val, errs = joi.String().Replace(regexp.MustCompile(`(\w+)@(\w+)`), "$2@$1").Validate("user@host")
+	assert.Empty(t, errs)
+	assert.Equal(t, "host@user", val)
+
+	val, errs = joi.String().Replace("-", "").Validate("123-456-789")
+	assert.Empty(t, errs)
+	assert.Equal(t, "123456789", val)
+
+	assert.Panics(t, func() { joi.String().Replace(1, "") })
+}
+
+func TestStringSchema_Truncate(t *testing.T) {
+	val, errs := joi.String().Max(5).Truncate().Validate("abcdefgh")
+	assert.Empty(t, errs)
+	assert.Equal(t, "abcde", val)
+
+	val, errs = joi.String().Truncate().Max(3).Validate("João")
+	assert.Empty(t, errs)
+	assert.Equal(t, "Joã", val)
+
+	val, errs = joi.String().Max(5).Truncate().Strict().Validate("abcdefgh")
+	assert.Equal(t, string(joi.StringMsgMax), errs[0].Type)
+	assert.Equal(t, "abcdefgh", val)
+
+	val, _ = joi.String().Encoding(joi.EncodingBytes).Max(3).Truncate().Validate("João")
+	assert.Equal(t, "Jo", val)
+
+	val, _ = joi.String().Encoding(joi.EncodingGraphemes).Max(2).Truncate().Validate("a👍🏽b")
+	assert.Equal(t, "a👍🏽", val)
+
+	base := joi.String().Max(2)
+	_ = base.Truncate()
+	_, errs = base.Validate("abc")
+	assert.Len(t, errs, 1)
+}
+
+func TestStringSchema_LengthCountsRunes(t *testing.T) {
+	_, errs := joi.String().Length(4).Validate("João")
+	assert.Empty(t, errs)
+
+	_, errs = joi.String().Max(3).Validate("日本語")
+	assert.Empty(t, errs)
+}
+
+func TestStringSchema_Encoding(t *testing.T) {
+	thumbs := "\U0001F44D\U0001F3FD" // thumbs up + skin tone modifier
+
+	_, errs := joi.String().Encoding(joi.EncodingGraphemes).Length(1).Validate(thumbs)
+	assert.Empty(t, errs)
+	_, errs = joi.String().Length(2).Validate(thumbs)
+	assert.Empty(t, errs)
+	_, errs = joi.String().Encoding(joi.EncodingBytes).Length(8).Validate(thumbs)
+	assert.Empty(t, errs)
+
+	schema := joi.String().Encoding(joi.EncodingBytes).Max(5).Encoding(joi.EncodingGraphemes).Min(2)
+	_, errs = schema.Validate("João")
+	assert.Empty(t, errs)
+	_, errs = schema.Validate(thumbs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, string(joi.StringMsgMax), errs[0].Type)
+	assert.Equal(t, string(joi.StringMsgMin), errs[1].Type)
+}
+
+func TestStringSchema_Normalize(t *testing.T) {
+	composed, decomposed := "Jo\u00e3o", "Joa\u0303o"
+
+	val, errs := joi.String().Normalize(joi.NFC).Length(4).Validate(decomposed)
+	assert.Empty(t, errs)
+	assert.Equal(t, composed, val)
+
+	val, errs = joi.String().Normalize(joi.NFD).Validate(composed)
+	assert.Empty(t, errs)
+	assert.Equal(t, decomposed, val)
+
+	val, errs = joi.String().Normalize(joi.NFKC).Validate("\ufb01")
+	assert.Empty(t, errs)
+	assert.Equal(t, "fi", val)
+
+	_, errs = joi.String().Normalize(joi.NFC).Strict().Validate(decomposed)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgNormalize), errs[0].Type)
+	assert.Equal(t, "value must be unicode normalized in the NFC form", errs[0].Msg)
+
+	_, errs = joi.String().Normalize(joi.NFKD).Strict().Validate("plain")
+	assert.Empty(t, errs)
+
+	assert.Panics(t, func() { joi.String().Normalize("NFX") })
+}
+
+func TestStringSchema_IP(t *testing.T) {
+	cases := []struct {
+		opts  joi.IPOptions
+		value string
+		valid bool
+	}{
+		{joi.IPOptions{}, "192.168.0.1", true},
+		{joi.IPOptions{}, "2001:db8::1", true},
+		{joi.IPOptions{}, "10.0.0.0/8", true},
+		{joi.IPOptions{}, "256.0.0.1", false},
+		{joi.IPOptions{}, "example.com", false},
+		{joi.IPOptions{Version: []joi.IPVersion{joi.IPv4}}, "2001:db8::1", false},
+		{joi.IPOptions{Version: []joi.IPVersion{joi.IPv6}}, "2001:db8::/32", true},
+		{joi.IPOptions{CIDR: joi.PresenceRequired}, "10.0.0.1", false},
+		{joi.IPOptions{CIDR: joi.PresenceRequired}, "10.0.0.0/8", true},
+		{joi.IPOptions{CIDR: joi.PresenceForbidden}, "10.0.0.0/8", false},
+		{joi.IPOptions{}, "10.0.0.0/33", false},
+	}
+	for _, c := range cases {
+		_, errs := joi.String().IP(c.opts).Validate(c.value)
+		assert.Equal(t, c.valid, len(errs) == 0, "%s %+v", c.value, c.opts)
+	}
+
+	_, errs := joi.String().IP(joi.IPOptions{Version: []joi.IPVersion{joi.IPv4, joi.IPv6}, CIDR: joi.PresenceForbidden}).Validate("x")
+	assert.Equal(t, string(joi.StringMsgIPVersion), errs[0].Type)
+	assert.Equal(t, "value must be a valid ip address of one of the following versions [ipv4, ipv6] with a forbidden CIDR", errs[0].Msg)
+
+	_, errs = joi.String().IP(joi.IPOptions{}).Validate("x")
+	assert.Equal(t, "value must be a valid ip address with a optional CIDR", errs[0].Msg)
+}
+
+func TestStringSchema_Hostname(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"localhost":             true,
+		"api-1.example.com":     true,
+		"example.com.":          true,
+		"10.0.0.1":              true,
+		"::1":                   true,
+		"-bad.example.com":      false,
+		"bad_host":              false,
+		"a..b":                  false,
+		strings.Repeat("a", 64): false,
+	} {
+		_, errs := joi.String().Hostname().Validate(value)
+		assert.Equal(t, valid, len(errs) == 0, value)
+	}
+}
+
+func TestStringSchema_Domain(t *testing.T) {
+	cases := []struct {
+		opts  joi.DomainOptions
+		value string
+		valid bool
+	}{
+		{joi.DomainOptions{}, "example.com", true},
+		{joi.DomainOptions{}, "sub.example.com.br", true},
+		{joi.DomainOptions{}, "são-paulo.com.br", true},
+		{joi.DomainOptions{}, "localhost", false},
+		{joi.DomainOptions{}, "example.123", false},
+		{joi.DomainOptions{}, "10.0.0.1", false},
+		{joi.DomainOptions{MinDomainSegments: 1}, "localhost", true},
+		{joi.DomainOptions{MinDomainSegments: 3}, "example.com", false},
+		{joi.DomainOptions{MaxDomainSegments: 2}, "a.example.com", false},
+		{joi.DomainOptions{AllowTLDs: []string{"com", "br"}}, "example.com.br", true},
+		{joi.DomainOptions{AllowTLDs: []string{"com"}}, "example.org", false},
+		{joi.DomainOptions{DenyTLDs: []string{".test"}}, "example.test", false},
+		{joi.DomainOptions{DenyTLDs: []string{"test"}}, "example.COM", true},
+	}
+	for _, c := range cases {
+		_, errs := joi.String().Domain(c.opts).Validate(c.value)
+		assert.Equal(t, c.valid, len(errs) == 0, "%s %+v", c.value, c.opts)
+	}
+	_, errs := joi.String().Domain(joi.DomainOptions{}).Validate("x")
+	assert.Equal(t, string(joi.StringMsgDomain), errs[0].Type)
+}
+
+func TestStringSchema_URI(t *testing.T) {
+	cases := []struct {
+		opts    joi.URIOptions
+		value   string
+		errType joi.StringMsg
+	}{
+		{joi.URIOptions{}, "https://example.com/a?b=c#d", ""},
+		{joi.URIOptions{}, "mailto:john@example.com", ""},
+		{joi.URIOptions{}, "/relative/path", joi.StringMsgURI},
+		{joi.URIOptions{}, "http://exa mple.com", joi.StringMsgURI},
+		{joi.URIOptions{}, "http://example.com/%zz", joi.StringMsgURI},
+		{joi.URIOptions{AllowRelative: true}, "/relative/path?x=1", ""},
+		{joi.URIOptions{AllowRelative: true}, "https://example.com", ""},
+		{joi.URIOptions{RelativeOnly: true}, "../up", ""},
+		{joi.URIOptions{RelativeOnly: true}, "https://example.com", joi.StringMsgURIRelative},
+		{joi.URIOptions{Scheme: []string{"https"}}, "HTTPS://example.com", ""},
+		{joi.URIOptions{Scheme: []string{"https", "wss"}}, "http://example.com", joi.StringMsgURIScheme},
+	}
+	for _, c := range cases {
+		_, errs := joi.String().URI(c.opts).Validate(c.value)
+		if c.errType == "" {
+			assert.Empty(t, errs, c.value)
+			continue
+		}
+		if assert.Len(t, errs, 1, c.value) {
+			assert.Equal(t, string(c.errType), errs[0].Type, c.value)
+		}
+	}
+
+	_, errs := joi.String().URI(joi.URIOptions{Scheme: []string{"https", "wss"}}).Validate("ftp://x")
+	assert.Equal(t, "value must be a valid uri with a scheme matching the [https, wss] pattern", errs[0].Msg)
+}
+
+func TestStringSchema_GUID(t *testing.T) {
+	v4 := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+	for value, valid := range map[string]bool{
+		v4:                                     true,
+		strings.ToUpper(v4):                    true,
+		"{" + v4 + "}":                         true,
+		strings.ReplaceAll(v4, "-", ""):        true,
+		strings.ReplaceAll(v4, "-", ":"):       true,
+		"00000000-0000-0000-0000-000000000000": true,
+		"3f2504e0-4f89:41d3-9a0c-0305e82c3301": false,
+		"{" + v4:                               false,
+		"3f2504e0-4f89-41d3-9a0c-0305e82c330":  false,
+		"zf2504e0-4f89-41d3-9a0c-0305e82c3301": false,
+	} {
+		_, errs := joi.String().UUID().Validate(value)
+		assert.Equal(t, valid, len(errs) == 0, value)
+	}
+
+	_, errs := joi.String().GUID(joi.GUIDOptions{Version: []int{4, 7}}).Validate(v4)
+	assert.Empty(t, errs)
+	_, errs = joi.String().GUID(joi.GUIDOptions{Version: []int{1}}).Validate(v4)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgGUID), errs[0].Type)
+	_, errs = joi.String().GUID(joi.GUIDOptions{Version: []int{4}}).Validate("3f2504e0-4f89-41d3-1a0c-0305e82c3301")
+	assert.Len(t, errs, 1, "invalid variant")
+
+	assert.PanicsWithValue(t, "joi: GUID version must be between 1 and 8, got 10", func() {
+		joi.String().GUID(joi.GUIDOptions{Version: []int{10}})
+	})
+	assert.Panics(t, func() { joi.String().GUID(joi.GUIDOptions{Version: []int{0}}) })
+}
+
+func TestStringSchema_Hex(t *testing.T) {
+	_, errs := joi.String().Hex(joi.HexOptions{}).Validate("0aF")
+	assert.Empty(t, errs)
+
+	_, errs = joi.String().Hex(joi.HexOptions{}).Validate("0xff")
+	assert.Equal(t, string(joi.StringMsgHex), errs[0].Type)
+
+	_, errs = joi.String().Hex(joi.HexOptions{ByteAligned: true}).Validate("abc")
+	assert.Equal(t, string(joi.StringMsgHexAlign), errs[0].Type)
+	assert.Equal(t, "value hex decoded representation must be byte aligned", errs[0].Msg)
+
+	val, errs := joi.String().Hex(joi.HexOptions{Lowercase: true}).Validate("DEADbeef")
+	assert.Empty(t, errs)
+	assert.Equal(t, "deadbeef", val)
+
+	_, errs = joi.String().Hex(joi.HexOptions{Lowercase: true}).Strict().Validate("DEADbeef")
+	assert.Equal(t, string(joi.StringMsgLower), errs[0].Type)
+}
+
+func TestStringSchema_Base64(t *testing.T) {
+	cases := []struct {
+		opts  joi.Base64Options
+		value string
+		valid bool
+	}{
+		{joi.Base64Options{}, "aGVsbG8gd29ybGQ=", true},
+		{joi.Base64Options{}, "aGVsbG8gd29ybGQ", false},
+		{joi.Base64Options{PaddingRequired: joi.Ptr(false)}, "aGVsbG8gd29ybGQ", true},
+		{joi.Base64Options{}, "+/+/", true},
+		{joi.Base64Options{URLSafe: true}, "+/+/", false},
+		{joi.Base64Options{URLSafe: true}, "-_-_", true},
+		{joi.Base64Options{}, "a===", false},
+		{joi.Base64Options{}, "not base64", false},
+	}
+	for _, c := range cases {
+		_, errs := joi.String().Base64(c.opts).Validate(c.value)
+		assert.Equal(t, c.valid, len(errs) == 0, "%s %+v", c.value, c.opts)
+	}
+}
+
+func TestStringSchema_DataURI(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"data:image/png;base64,iVBORw0KGgo=":          true,
+		"data:text/plain;charset=utf-8,hello%20world": true,
+		"data:,hello":                                  true,
+		"data:image/png;base64,iVBORw0KGgo":            false,
+		"data:text/plain,bad%zz":                       false,
+		"image/png;base64,iVBORw0KGgo=":                false,
+		"data:image/png;base64;charset=x,iVBORw0KGgo=": false,
+	} {
+		_, errs := joi.String().DataURI(joi.Base64Options{}).Validate(value)
+		assert.Equal(t, valid, len(errs) == 0, value)
+	}
+}
+
+func TestStringSchema_TokenAndAlphanum(t *testing.T) {
+	_, errs := joi.String().Token().Validate("api_KEY_01")
+	assert.Empty(t, errs)
+	_, errs = joi.String().Token().Validate("api-key")
+	assert.Equal(t, string(joi.StringMsgToken), errs[0].Type)
+
+	_, errs = joi.String().Alphanum().Validate("abc123")
+	assert.Empty(t, errs)
+	_, errs = joi.String().Alphanum().Validate("abc_123")
+	assert.Equal(t, "value must only contain alpha-numeric characters", errs[0].Msg)
+	_, errs = joi.String().Alphanum().Validate("ação")
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,310 @@
+package joi_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+type Role string
+
+type Audit struct {
+	CreatedBy string `json:"createdBy,omitempty" joi:"required"`
+}
+
+type Address struct {
+	City string `json:"city" joi:"string,min=2,required"`
+	Zip  string `json:"zip,omitempty" joi:"regex=^\\d{5}$"`
+}
+
+type User struct {
+	Audit
+	Name      string            `json:"name,omitempty" joi:"string,min=3,max=20,required"`
+	Age       int               `json:"age,omitempty" joi:"integer,min=18"`
+	Role      Role              `json:"role,omitempty" joi:"valid=admin|user"`
+	Level     int               `json:"level,omitempty" joi:"valid=1|2|3,default=1"`
+	Email     *string           `json:"email" joi:"email"`
+	Address   *Address          `json:"address"`
+	Tags      []string          `json:"tags" joi:"max=2"`
+	Others    []Address         `json:"others"`
+	Meta      map[string]string `json:"meta" joi:"max=1"`
+	BirthDate time.Time         `json:"birthDate"`
+	Password  string            `json:"-"`
+	Internal  string            `json:"internal" joi:"-"`
+	secret    string
+}
+
+func validUser() User {
+	email := "john@doe.com"
+	return User{
+		Audit:   Audit{CreatedBy: "admin"},
+		Name:    "John",
+		Age:     30,
+		Role:    "admin",
+		Level:   2,
+		Email:   &email,
+		Address: &Address{City: "Recife", Zip: "50000"},
+		Tags:    []string{"a"},
+		Others:  []Address{{City: "Olinda"}},
+		Meta:    map[string]string{"k": "v"},
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	u := validUser()
+	assert.Empty(t, joi.ValidateStruct(u))
+	assert.Empty(t, joi.ValidateStruct(&u))
+}
+
+func TestValidateStruct_Errors(t *testing.T) {
+	u := validUser()
+	bad := "not-an-email"
+	u.CreatedBy = ""
+	u.Name = "Jo"
+	u.Age = 10
+	u.Role = "root"
+	u.Level = 5
+	u.Email = &bad
+	u.Address.Zip = "123"
+	u.Tags = []string{"a", "b", "c"}
+	u.Others = []Address{{City: "X"}}
+	u.Meta = map[string]string{"a": "1", "b": "2"}
+	u.Internal = "anything"
+
+	errs := joi.ValidateStruct(u)
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{"createdBy", "name", "age", "role", "level", "email", "address.zip", "tags", "others[0].city", "meta"}, paths)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+	assert.Equal(t, string(joi.StringMsgMin), errs[1].Type)
+}
+
+func TestValidateStruct_RequiredAndNil(t *testing.T) {
+	errs := joi.ValidateStruct(User{})
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "createdBy", errs[0].Path)
+	assert.Equal(t, "name", errs[1].Path)
+
+	type badTag struct {
+		Name string `joi:"min=abc"`
+	}
+	assert.Panics(t, func() { joi.ValidateStruct(badTag{}) })
+}
+
+func TestStructSchema(t *testing.T) {
+	schema, err := joi.StructSchema(User{})
+	assert.NoError(t, err)
+	again, _ := joi.StructSchema(&User{})
+	assert.Same(t, schema, again, "schemas are built once per type")
+
+	parsed, errs := schema.Validate(map[string]any{"createdBy": "x", "name": "John"})
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, parsed.(map[string]any)["level"])
+
+	type badTag struct {
+		Name string `joi:"string,unknown"`
+	}
+	_, err = joi.StructSchema(badTag{})
+	assert.ErrorContains(t, err, `unsupported tag option "unknown"`)
+
+	type badLimit struct {
+		Name string `joi:"min=abc"`
+	}
+	_, err = joi.StructSchema(badLimit{})
+	assert.Error(t, err)
+}
+
+func TestStructSchema_Recursive(t *testing.T) {
+	type Node struct {
+		Name     string  `json:"name" joi:"required"`
+		Children []*Node `json:"children"`
+	}
+	errs := joi.ValidateStruct(Node{Name: "root", Children: []*Node{{Name: "child"}}})
+	assert.Empty(t, errs)
+}
+
+func TestValidateStruct_NotAStruct(t *testing.T) {
+	for _, v := range []any{nil, map[string]any{}, "x", time.Now()} {
+		errs := joi.ValidateStruct(v)
+		assert.Len(t, errs, 1, "%v", v)
+		assert.Equal(t, string(joi.ObjectMsgBase), errs[0].Type)
+		assert.Equal(t, "value must be an object", errs[0].Msg)
+	}
+
+	errs := joi.ValidateStructWithOpts(nil, joi.ValidateOptions{Path: joi.Ptr("body")})
+	assert.Equal(t, "body", errs[0].Path)
+	assert.Equal(t, "body must be an object", errs[0].Msg)
+}
+
+func TestStructSchema_QuotedTagValue(t *testing.T) {
+	type country struct {
+		Code string `json:"code" joi:"regex='^[A-Z]{2,3}$',required"`
+	}
+	assert.Empty(t, joi.ValidateStruct(country{Code: "BRA"}))
+	errs := joi.ValidateStruct(country{Code: "B"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgRegex), errs[0].Type)
+
+	type unterminated struct {
+		Code string `joi:"regex='^[A-Z]{2,3}$"`
+	}
+	_, err := joi.StructSchema(unterminated{})
+	assert.ErrorContains(t, err, "unterminated quote")
+}
+
+type shadowInner struct {
+	ID   string `json:"id" joi:"max=1"`
+	Code string // untagged, so hidden by shadowTagged.Code
+}
+
+type shadowTagged struct {
+	Code string `json:"Code" joi:"min=3"`
+}
+
+type shadowOuter struct {
+	ID string `json:"id" joi:"min=2"`
+	shadowInner
+	shadowTagged
+}
+
+type conflictA struct {
+	Name string
+}
+
+type conflictB struct {
+	Name string
+}
+
+type conflicting struct {
+	conflictA
+	conflictB
+}
+
+func TestStructSchema_EmbeddedDominance(t *testing.T) {
+	// as json.Marshal, "id" is Outer.ID and "Code" the tagged shadowTagged.Code
+	v := shadowOuter{ID: "x", shadowInner: shadowInner{ID: "ok", Code: "c"}, shadowTagged: shadowTagged{Code: "cc"}}
+	errs := joi.ValidateStruct(v)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "id", errs[0].Path)
+	assert.Equal(t, string(joi.StringMsgMin), errs[0].Type)
+	assert.Equal(t, "Code", errs[1].Path)
+
+	parsed, errs := joi.Object(nil).Unknown(true).Validate(conflicting{conflictA{"a"}, conflictB{"b"}})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{}, parsed, "conflicting fields are left out")
+}
+
+type treeNode struct {
+	Name     string     `json:"name" joi:"required,min=3"`
+	Children []treeNode `json:"children"`
+	Parent   *treeNode  `json:"parent" joi:"forbidden"`
+}
+
+func TestStructSchema_RecursiveValidatesEveryLevel(t *testing.T) {
+	tree := treeNode{Name: "root", Children: []treeNode{
+		{Name: "leaf"},
+		{Name: "node", Children: []treeNode{{Name: "x"}}},
+	}}
+	errs := joi.ValidateStruct(tree)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "children[1].children[0].name", errs[0].Path)
+	assert.Equal(t, string(joi.StringMsgMin), errs[0].Type)
+
+	tree.Children[0].Parent = &treeNode{Name: "root"}
+	errs = joi.ValidateStruct(tree)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "children[0].parent", errs[0].Path)
+}
+
+type mutualA struct {
+	X string   `json:"x" joi:"min=3"`
+	B *mutualB `json:"b"`
+}
+
+type mutualB struct {
+	Y string   `json:"y" joi:"min=3"`
+	A *mutualA `json:"a"`
+}
+
+type mutualC struct {
+	X string   `json:"x" joi:"min=3"`
+	D *mutualD `json:"d"`
+}
+
+type mutualD struct {
+	Y string   `json:"y" joi:"min=3"`
+	C *mutualC `json:"c"`
+}
+
+func TestStructSchema_MutuallyRecursive(t *testing.T) {
+	// B is validated (and cached) first, then A
+	errs := joi.ValidateStruct(mutualB{Y: "yyy", A: &mutualA{X: "x"}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "a.x", errs[0].Path)
+
+	errs = joi.ValidateStruct(mutualA{X: "xxx", B: &mutualB{Y: "b"}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "b.y", errs[0].Path)
+
+	// C is validated first, then D
+	errs = joi.ValidateStruct(mutualC{X: "xxx", D: &mutualD{Y: "d", C: &mutualC{X: "c"}}})
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "d.y", errs[0].Path)
+	assert.Equal(t, "d.c.x", errs[1].Path)
+
+	errs = joi.ValidateStruct(mutualD{Y: "yyy", C: &mutualC{X: "c"}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "c.x", errs[0].Path)
+}
+
+func TestObjectSchema_StructValue(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"city": joi.String().Min(3),
+		"zip":  joi.String(),
+	})
+
+	parsed, errs := schema.Validate(&Address{City: "Recife", Zip: "1"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"city": "Recife", "zip": "1"}, parsed)
+
+	_, errs = schema.Validate(Address{City: "X"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "city", errs[0].Path)
+
+	_, errs = joi.Array().Items(joi.Number().Max(2)).Validate([]int{1, 3})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "[1]", errs[0].Path)
+}
+
+func TestValidateStruct_Concurrent(t *testing.T) {
+	type Fresh struct {
+		Name string `json:",omitempty" joi:"required"`
+	}
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			assert.Len(t, joi.ValidateStruct(Fresh{}), 1)
+		})
+	}
+	wg.Wait()
+}
+
+func TestStructSchema_StringEncoding(t *testing.T) {
+	type Profile struct {
+		Nick string `json:"nick" joi:"encoding=bytes,max=4,normalize=NFC"`
+	}
+	errs := joi.ValidateStruct(Profile{Nick: "João"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgMax), errs[0].Type)
+
+	type badEncoding struct {
+		Nick string `joi:"encoding=utf16"`
+	}
+	_, err := joi.StructSchema(badEncoding{})
+	assert.ErrorContains(t, err, "unknown encoding")
+}
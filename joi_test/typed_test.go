@@ -0,0 +1,79 @@
+package joi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTString(t *testing.T) {
+	name, errs := joi.TString(joi.String().Trim(true).Min(3).Required()).Validate("  John ")
+	assert.Empty(t, errs)
+	assert.Equal(t, "John", name)
+
+	name, errs = joi.TString(joi.String().Min(3)).Validate("Jo")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "", name)
+
+	name, errs = joi.TString().Validate(nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "", name)
+}
+
+func TestTNumber(t *testing.T) {
+	n, errs := joi.TNumber[int64](joi.Number().Min(1)).Validate("42")
+	assert.Empty(t, errs)
+	assert.Equal(t, int64(42), n)
+
+	u, errs := joi.TNumber[uint8]().Validate(300)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgDecode), errs[0].Type)
+	assert.Equal(t, uint8(0), u)
+
+	f, errs := joi.TNumber[float64]().Validate(1.5)
+	assert.Empty(t, errs)
+	assert.Equal(t, 1.5, f)
+}
+
+func TestTBooleanAndTDate(t *testing.T) {
+	b, errs := joi.TBoolean().Validate("true")
+	assert.Empty(t, errs)
+	assert.True(t, b)
+
+	d, errs := joi.TDate(joi.Date().Required()).Validate("2024-01-02")
+	assert.Empty(t, errs)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), d)
+}
+
+func TestTArray(t *testing.T) {
+	tags, errs := joi.TArray[string](joi.Array().Items(joi.String().Lower()).Max(3)).Validate([]any{"A", "B"})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"a", "b"}, tags)
+}
+
+func TestTObject(t *testing.T) {
+	type Account struct {
+		Name  string `json:"name,omitempty" joi:"min=3,required"`
+		Admin bool   `json:"admin" joi:"default=false"`
+	}
+
+	account, errs := joi.TObject[Account]().Validate(map[string]any{"name": "John"})
+	assert.Empty(t, errs)
+	assert.Equal(t, Account{Name: "John"}, account)
+
+	_, errs = joi.TObject[Account]().Validate(map[string]any{})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "name", errs[0].Path)
+
+	schema := joi.TObject[Customer](joi.Object(map[string]joi.Schema{"name": joi.String().Upper()}))
+	customer, errs := schema.Validate(map[string]any{"name": "ana"})
+	assert.Empty(t, errs)
+	assert.Equal(t, Customer{Name: "ANA"}, customer)
+
+	nested := joi.Object(map[string]joi.Schema{"customer": schema.Schema()})
+	_, errs = nested.Validate(map[string]any{"customer": map[string]any{"name": 1}})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "customer.name", errs[0].Path)
+}
@@ -1,6 +1,7 @@
 package joi_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -76,6 +77,11 @@ func TestParseDate(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, sec, tm.Unix())
 
+	// date only
+	tm, ok = joi.ParseDate("2024-01-01")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), tm)
+
 	// invalid
 	_, ok = joi.ParseDate("not-a-date")
 	assert.False(t, ok)
@@ -126,3 +132,102 @@ func TestRunValidation_FullFlow(t *testing.T) {
 	assert.Contains(t, errs[1].String(), `validation error at "obj.a"`)
 	assert.Contains(t, errs[1].String(), "E MyLabel obj.a B")
 }
+
+func TestRunValidationWithOpts_AbortEarly(t *testing.T) {
+	calls := 0
+	failing := joi.Rule{
+		Name: "failing",
+		Msg:  "fail",
+		Fn: func(r joi.Rule, path string, value any) (any, *joi.ValidationError) {
+			calls++
+			return value, &joi.ValidationError{Path: path}
+		},
+	}
+	rules := []joi.Rule{failing, failing, failing}
+
+	_, errs := joi.RunValidationWithOpts(rules, "value", "x", joi.ValidateOptions{AbortEarly: true})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, errs = joi.RunValidationWithOpts(rules, "value", "x", joi.ValidateOptions{MaxErrors: 2})
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 2, calls)
+}
+
+func TestParsePath(t *testing.T) {
+	assert.Equal(t, []any{}, joi.ParsePath(""))
+	assert.Equal(t, []any{"a"}, joi.ParsePath("a"))
+	assert.Equal(t, []any{"users", 0, "name"}, joi.ParsePath("users[0].name"))
+	assert.Equal(t, []any{"m", 1, 2}, joi.ParsePath("m[1][2]"))
+}
+
+func TestRunValidation_StructuredError(t *testing.T) {
+	rules := []joi.Rule{{
+		Name: "string_min",
+		Msg:  "{{#label}} too short",
+		Args: map[string]any{"limit": 3},
+		Fn: func(r joi.Rule, path string, value any) (any, *joi.ValidationError) {
+			return value, &joi.ValidationError{Path: path}
+		},
+	}}
+
+	_, errs := joi.RunValidation(rules, "name", "users[1].name", "ab")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "string_min", errs[0].Type)
+	assert.Equal(t, "ab", errs[0].Value)
+	assert.Equal(t, []any{"users", 1, "name"}, errs[0].Segments)
+	assert.Equal(t, 3, errs[0].Context["limit"])
+	assert.Equal(t, "name", errs[0].Context["label"])
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	n, ok := joi.NormalizeNumber(int16(-3))
+	assert.True(t, ok)
+	assert.Equal(t, int64(-3), n)
+
+	n, ok = joi.NormalizeNumber(uint8(3))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), n)
+
+	n, ok = joi.NormalizeNumber(float32(1.5))
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, n)
+
+	_, ok = joi.NormalizeNumber("3")
+	assert.False(t, ok)
+	_, ok = joi.NormalizeNumber(nil)
+	assert.False(t, ok)
+}
+
+func TestCompareNumbers(t *testing.T) {
+	cmp, ok := joi.CompareNumbers(uint64(math.MaxUint64), int64(math.MaxInt64))
+	assert.True(t, ok)
+	assert.Equal(t, 1, cmp)
+
+	cmp, ok = joi.CompareNumbers(int64(-1), uint64(0))
+	assert.True(t, ok)
+	assert.Equal(t, -1, cmp)
+
+	cmp, ok = joi.CompareNumbers(2, 2.0)
+	assert.True(t, ok)
+	assert.Equal(t, 0, cmp)
+
+	_, ok = joi.CompareNumbers("2", 2)
+	assert.False(t, ok)
+}
+
+func TestReach(t *testing.T) {
+	value := map[string]any{"a": map[string]any{"b": []any{"x", "y"}}}
+
+	v, ok := joi.Reach(value, []any{"a", "b", 1})
+	assert.True(t, ok)
+	assert.Equal(t, "y", v)
+
+	_, ok = joi.Reach(value, []any{"a", "c"})
+	assert.False(t, ok)
+	_, ok = joi.Reach(value, []any{"a", "b", 5})
+	assert.False(t, ok)
+	_, ok = joi.Reach("scalar", []any{"a"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,190 @@
+package joi_test
+
+import (
+	"testing"
+
+	"github.com/leandroluk/go-joi/joi"
+	"github.com/stretchr/testify/assert"
+)
+
+func paymentSchema() *joi.ObjectSchema {
+	return joi.Object(map[string]joi.Schema{
+		"paymentMethod": joi.String().Valid([]any{"card", "pix", "boleto"}),
+		"cardNumber": joi.String().When("paymentMethod", joi.WhenOpts{
+			Is:        joi.String().Valid([]any{"card"}),
+			Then:      joi.String().Min(12).Required(),
+			Otherwise: joi.Any[joi.Schema]().Valid([]any{}),
+		}),
+	})
+}
+
+func TestWhen_ParsedSibling(t *testing.T) {
+	// "card" sorts before "method" but is validated after it
+	schema := joi.Object(map[string]joi.Schema{
+		"method": joi.String().Trim(true).Lower(),
+		"card": joi.String().When("method", joi.WhenOpts{
+			Is:   joi.String().Valid([]any{"card"}),
+			Then: joi.String().Required(),
+		}),
+	})
+
+	val, errs := schema.Validate(map[string]any{"method": " CARD "})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "card", errs[0].Path)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+	assert.Equal(t, map[string]any{"method": "card"}, val)
+
+	_, errs = schema.Validate(map[string]any{"method": " pix "})
+	assert.Empty(t, errs)
+}
+
+func TestWhen_SiblingReference(t *testing.T) {
+	schema := paymentSchema()
+
+	_, errs := schema.Validate(map[string]any{"paymentMethod": "card"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "cardNumber", errs[0].Path)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+
+	_, errs = schema.Validate(map[string]any{"paymentMethod": "card", "cardNumber": "123"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.StringMsgMin), errs[0].Type)
+
+	_, errs = schema.Validate(map[string]any{"paymentMethod": "card", "cardNumber": "4111111111111111"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"paymentMethod": "pix"})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"paymentMethod": "pix", "cardNumber": "4111111111111111"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.AnyMsgValid), errs[0].Type)
+}
+
+func TestWhen_MissingReferenceUsesOtherwise(t *testing.T) {
+	schema := paymentSchema()
+
+	_, errs := schema.Validate(map[string]any{})
+	assert.Empty(t, errs)
+}
+
+func TestWhen_TruthyByDefault(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"hasCompany":  joi.Boolean(),
+		"companyName": joi.String().When("hasCompany", joi.WhenOpts{Then: joi.String().Required()}),
+	})
+
+	_, errs := schema.Validate(map[string]any{"hasCompany": true})
+	assert.Len(t, errs, 1)
+
+	_, errs = schema.Validate(map[string]any{"hasCompany": false})
+	assert.Empty(t, errs)
+}
+
+func TestWhen_NestedReference(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"address": joi.Object(map[string]joi.Schema{"country": joi.String()}),
+		"zip": joi.String().When("address.country", joi.WhenOpts{
+			Is:   joi.String().Valid([]any{"BR"}),
+			Then: joi.String().Length(8),
+		}),
+	})
+
+	_, errs := schema.Validate(map[string]any{"address": map[string]any{"country": "BR"}, "zip": "123"})
+	assert.Len(t, errs, 1)
+
+	_, errs = schema.Validate(map[string]any{"address": map[string]any{"country": "US"}, "zip": "123"})
+	assert.Empty(t, errs)
+}
+
+func TestWhen_Switch(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"kind": joi.String(),
+		"value": joi.Any[joi.Schema]().When("kind", joi.WhenOpts{
+			Switch: []joi.WhenCase{
+				{Is: joi.String().Valid([]any{"number"}), Then: joi.Number()},
+				{Is: joi.String().Valid([]any{"bool"}), Then: joi.Boolean()},
+			},
+			Otherwise: joi.String(),
+		}),
+	})
+
+	_, errs := schema.Validate(map[string]any{"kind": "number", "value": 1})
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]any{"kind": "bool", "value": 1})
+	assert.True(t, errs.Has(string(joi.BooleanMsgBase)))
+
+	_, errs = schema.Validate(map[string]any{"kind": "other", "value": 1})
+	assert.True(t, errs.Has(string(joi.StringMsgBase)))
+}
+
+func TestWhen_OwnValue(t *testing.T) {
+	schema := joi.Any[joi.Schema]().When(joi.Number(), joi.WhenOpts{
+		Then:      joi.Number().Min(10),
+		Otherwise: joi.String().Min(3),
+	})
+
+	_, errs := schema.Validate(5)
+	assert.True(t, errs.Has(string(joi.NumberMsgMin)))
+
+	_, errs = schema.Validate(15)
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate("ab")
+	assert.True(t, errs.Has(string(joi.StringMsgMin)))
+}
+
+func TestWhen_DoesNotAliasBase(t *testing.T) {
+	base := joi.String()
+	conditional := base.When("flag", joi.WhenOpts{Then: joi.String().Required()})
+
+	_, errs := joi.Object(map[string]joi.Schema{"flag": joi.Boolean(), "a": base}).
+		Validate(map[string]any{"flag": true})
+	assert.Empty(t, errs)
+
+	_, errs = joi.Object(map[string]joi.Schema{"flag": joi.Boolean(), "a": conditional}).
+		Validate(map[string]any{"flag": true})
+	assert.Len(t, errs, 1)
+}
+
+func TestAnySchema_WhenBranchKeepsPresence(t *testing.T) {
+	schema := joi.Object(nil).Keys(
+		joi.Key("type", joi.String()),
+		joi.Key("code", joi.String().Optional().When("type", joi.WhenOpts{
+			Is:   joi.String().Valid([]any{"coupon"}),
+			Then: joi.String().Min(3),
+		})),
+	)
+
+	_, errs := schema.ValidateWithOpts(map[string]any{"type": "coupon"}, joi.ValidateOptions{Presence: joi.PresenceRequired})
+	assert.Empty(t, errs)
+}
+
+func TestWhen_ObjectBranchKeepsKeys(t *testing.T) {
+	schema := joi.Object(map[string]joi.Schema{
+		"type":  joi.String().Trim(true).Valid([]any{"person", "company"}),
+		"taxId": joi.String(),
+	}).Pattern(`^x-`, joi.String()).When(
+		joi.Object(map[string]joi.Schema{"type": joi.String().Valid([]any{"company"})}).Unknown(true),
+		joi.WhenOpts{Then: joi.Object(map[string]joi.Schema{"taxId": joi.String().Required()})},
+	)
+
+	val, errs := schema.Validate(map[string]any{"type": " company ", "x-trace": "1"})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "taxId", errs[0].Path)
+	assert.Equal(t, string(joi.AnyMsgRequired), errs[0].Type)
+	assert.Equal(t, map[string]any{"type": "company", "x-trace": "1"}, val)
+
+	val, errs = schema.Validate(map[string]any{"type": "company", "taxId": "123"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]any{"type": "company", "taxId": "123"}, val)
+
+	_, errs = schema.Validate(map[string]any{"type": "company", "taxId": "123", "other": true})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, string(joi.ObjectMsgUnknown), errs[0].Type)
+	assert.Equal(t, "other", errs[0].Path)
+
+	_, errs = schema.Validate(map[string]any{"type": "person"})
+	assert.Empty(t, errs)
+}